@@ -0,0 +1,140 @@
+// Copyright (c) 2016-2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package apiconfig defines the configuration accepted by the various
+// backend datastore drivers (currently etcdv3 and Kubernetes).
+package apiconfig
+
+// CalicoAPIConfigSpec holds the configuration accepted by the Calico API
+// client, in particular the Kubernetes datastore driver in
+// libcalico-go/lib/backend/k8s.
+type CalicoAPIConfigSpec struct {
+	DatastoreType string `json:"datastoreType,omitempty" envconfig:"DATASTORE_TYPE" default:"etcdv3"`
+
+	// Inline settings for the Kubernetes datastore driver.
+	Kubeconfig       string `json:"kubeconfig,omitempty" envconfig:"KUBECONFIG"`
+	KubeconfigInline string `json:"kubeconfigInline,omitempty" envconfig:"KUBECONFIG_INLINE"`
+
+	K8sAPIEndpoint           string  `json:"k8sAPIEndpoint,omitempty" envconfig:"K8S_API_ENDPOINT"`
+	K8sKeyFile               string  `json:"k8sKeyFile,omitempty" envconfig:"K8S_KEY_FILE"`
+	K8sCertFile              string  `json:"k8sCertFile,omitempty" envconfig:"K8S_CERT_FILE"`
+	K8sCAFile                string  `json:"k8sCAFile,omitempty" envconfig:"K8S_CA_FILE"`
+	K8sAPIToken              string  `json:"k8sAPIToken,omitempty" envconfig:"K8S_API_TOKEN"`
+	K8sInsecureSkipTLSVerify bool    `json:"k8sInsecureSkipTLSVerify,omitempty" envconfig:"K8S_INSECURE_SKIP_TLS_VERIFY"`
+	K8sDisableNodePoll       bool    `json:"k8sDisableNodePoll,omitempty" envconfig:"K8S_DISABLE_NODE_POLL"`
+	K8sClientQPS             float32 `json:"k8sClientQPS,omitempty" envconfig:"K8S_CLIENT_QPS"`
+	K8sCurrentContext        string  `json:"k8sCurrentContext,omitempty" envconfig:"K8S_CURRENT_CONTEXT"`
+	K8sUsePodCIDR            bool    `json:"k8sUsePodCIDR,omitempty" envconfig:"K8S_USE_POD_CIDR"`
+
+	// K8sFieldManager identifies this client to the API server for the
+	// purposes of Server-Side Apply field ownership tracking. Required
+	// when K8sApplyMode is ApplyModeServerSide.
+	K8sFieldManager string `json:"k8sFieldManager,omitempty" envconfig:"K8S_FIELD_MANAGER"`
+
+	// K8sApplyMode selects how KubeClient.Apply writes resources: the
+	// legacy Create-then-Update ClientSide behavior, or ApplyModeServerSide
+	// (Server-Side Apply, PATCH with application/apply-patch+yaml).
+	// Defaults to ApplyModeClientSide when empty. Only resource kinds
+	// whose client implements resources.ServerSideApplier support
+	// ApplyModeServerSide; Apply returns
+	// errors.ErrorOperationNotSupported for any other kind rather than
+	// silently falling back to ClientSide.
+	K8sApplyMode ApplyMode `json:"k8sApplyMode,omitempty" envconfig:"K8S_APPLY_MODE"`
+
+	// K8sApplyForceConflicts, when true and K8sApplyMode is
+	// ApplyModeServerSide, reassigns ownership of conflicting fields to
+	// K8sFieldManager instead of Apply returning
+	// errors.ErrorFieldManagerConflict.
+	K8sApplyForceConflicts bool `json:"k8sApplyForceConflicts,omitempty" envconfig:"K8S_APPLY_FORCE_CONFLICTS"`
+
+	// Impersonation lets the driver act as another user, e.g. when a
+	// multi-tenant proxy in front of the API server needs to attribute
+	// writes to the tenant that triggered them rather than to the
+	// driver's own identity. Only applied when K8sImpersonateUserName is
+	// non-empty.
+	K8sImpersonateUserName  string              `json:"k8sImpersonateUserName,omitempty" envconfig:"K8S_IMPERSONATE_USER_NAME"`
+	K8sImpersonateUserUID   string              `json:"k8sImpersonateUserUID,omitempty" envconfig:"K8S_IMPERSONATE_USER_UID"`
+	K8sImpersonateGroups    []string            `json:"k8sImpersonateGroups,omitempty" envconfig:"K8S_IMPERSONATE_GROUPS"`
+	K8sImpersonateUserExtra map[string][]string `json:"k8sImpersonateUserExtra,omitempty"`
+
+	// K8sExecCredential configures an exec credential plugin (e.g. a cloud
+	// provider's IAM authenticator, or a workload-identity token
+	// exchanger) that mints and refreshes its own short-lived tokens.
+	// client-go only runs it when no static bearer token, basic auth or
+	// client certificate is configured; otherwise those are used instead.
+	K8sExecCredential *ExecCredentialConfig `json:"k8sExecCredential,omitempty"`
+
+	// K8sAPITokenFile is a bearer-token file (e.g. a projected Kubernetes
+	// service account token used for workload identity) that, unlike
+	// K8sAPIToken, is periodically re-read by client-go, so it picks up
+	// rotations without restarting.
+	K8sAPITokenFile string `json:"k8sAPITokenFile,omitempty" envconfig:"K8S_API_TOKEN_FILE"`
+
+	// K8sWireguardAnnotationPrefix is the node annotation prefix
+	// calico/node writes its WireGuard public key and interface address
+	// under (e.g. "<prefix>PublicKey"/"<prefix>InterfaceAddr"), read back
+	// by the HostConfig derivation to surface WireguardPublicKey/
+	// WireguardInterfaceAddr entries. Defaults to
+	// "projectcalico.org/Wireguard" when empty.
+	K8sWireguardAnnotationPrefix string `json:"k8sWireguardAnnotationPrefix,omitempty" envconfig:"K8S_WIREGUARD_ANNOTATION_PREFIX"`
+
+	// K8sWindowsTunnelMode is one of "none" or "vxlan", controlling how
+	// HostConfig derivation handles Windows nodes (IPIP isn't supported on
+	// Windows). Defaults to "none" when empty.
+	K8sWindowsTunnelMode string `json:"k8sWindowsTunnelMode,omitempty" envconfig:"K8S_WINDOWS_TUNNEL_MODE"`
+
+	// K8sPodCIDRTunnelOffset is added to a PodCIDR's network address to
+	// derive its tunnel address, so it can be aligned with a
+	// differently-configured host-local IPAM rangeStart. A nil pointer
+	// means "not configured" and defaults to 1 (the historical ".1"
+	// behavior); unlike a plain int, this lets an operator explicitly
+	// configure an offset of 0.
+	K8sPodCIDRTunnelOffset *int `json:"k8sPodCIDRTunnelOffset,omitempty"`
+}
+
+// ExecCredentialConfig configures an exec-plugin credential source for the
+// Kubernetes datastore driver, mirroring the fields of
+// k8s.io/client-go/tools/clientcmd/api.ExecConfig that the driver plumbs
+// through.
+type ExecCredentialConfig struct {
+	Command            string                 `json:"command"`
+	Args               []string               `json:"args,omitempty"`
+	Env                []ExecCredentialEnvVar `json:"env,omitempty"`
+	APIVersion         string                 `json:"apiVersion,omitempty"`
+	InstallHint        string                 `json:"installHint,omitempty"`
+	ProvideClusterInfo bool                   `json:"provideClusterInfo,omitempty"`
+}
+
+// ExecCredentialEnvVar is a single environment variable passed to an exec
+// credential plugin.
+type ExecCredentialEnvVar struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ApplyMode selects the write semantics used by KubeClient.Apply.
+type ApplyMode string
+
+const (
+	// ApplyModeClientSide does a Create, falling back to an Update if the
+	// resource already exists. This is the historical default, but it can
+	// clobber fields owned by other writers and races on conflicting
+	// writes.
+	ApplyModeClientSide ApplyMode = "ClientSide"
+
+	// ApplyModeServerSide issues a Server-Side Apply PATCH, so the API
+	// server merges the write with fields owned by other managers instead
+	// of overwriting them.
+	ApplyModeServerSide ApplyMode = "ServerSide"
+)
@@ -0,0 +1,337 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fake provides an in-memory api.Client for unit tests that
+// consume the Kubernetes datastore driver (Felix, kube-controllers,
+// calicoctl) without needing a real apiserver or envtest.
+//
+// Client is a generic in-memory double: it implements api.Client's
+// Create/Update/Apply/Delete/Get/List/Watch semantics itself, keyed off
+// model.Key.String(), rather than being wired through the real
+// resources.K8sResourceClient implementations (e.g. via a fake
+// client-go Clientset and an httptest-backed rest.RESTClient for the CRD
+// group). That means it's useful for exercising retry/backoff and
+// generic Watch-consumer logic against realistic ResourceVersion and
+// event semantics, but it does NOT catch bugs specific to a given
+// resources.K8sResourceClient's own Create/Update/conversion code (KDD
+// bugs) - callers that need that level of fidelity still need a real
+// KDD or envtest.
+package fake
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	cerrors "github.com/projectcalico/calico/libcalico-go/lib/errors"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+// FaultFunc lets a test inject a failure (or alternate behavior) for a
+// given Kind on the Nth call to a given operation. Returning (nil, false)
+// means "don't interfere, let the normal in-memory implementation run".
+type FaultFunc func(op string, key model.Key, attempt int) (err error, inject bool)
+
+// Client is an in-memory api.Client. It's not safe to share between
+// goroutines that expect to see each other's Watch events synchronously,
+// but all methods are safe to call concurrently.
+type Client struct {
+	mu sync.Mutex
+
+	// objects holds the current value of every key, keyed by the key's
+	// string representation.
+	objects map[string]*model.KVPair
+
+	// resourceVersion is a monotonically increasing counter used to hand
+	// out ResourceVersions, mimicking the Kubernetes apiserver.
+	resourceVersion int64
+
+	// callCounts tracks how many times each (operation, Kind) pair has
+	// been invoked, for fault injection.
+	callCounts map[string]int
+
+	faults []FaultFunc
+
+	watchers []*watcher
+}
+
+// NewClient returns an in-memory api.Client seeded with seed.
+func NewClient(seed ...*model.KVPair) *Client {
+	c := &Client{
+		objects:    make(map[string]*model.KVPair),
+		callCounts: make(map[string]int),
+	}
+	for _, kvp := range seed {
+		c.resourceVersion++
+		stored := copyKVP(kvp)
+		stored.Revision = c.revisionString()
+		c.objects[kvp.Key.String()] = stored
+	}
+	return c
+}
+
+// InjectFault registers f to be consulted before every operation. Faults
+// are consulted in registration order; the first to return inject=true
+// wins.
+func (c *Client) InjectFault(f FaultFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.faults = append(c.faults, f)
+}
+
+func (c *Client) revisionString() string {
+	return strconv.FormatInt(c.resourceVersion, 10)
+}
+
+func copyKVP(kvp *model.KVPair) *model.KVPair {
+	cp := *kvp
+	return &cp
+}
+
+// checkFault consults registered faults for (op, key), counting the call
+// first so "Nth call" fault specs are 1-indexed.
+func (c *Client) checkFault(op string, key model.Key) error {
+	countKey := fmt.Sprintf("%s/%s", op, faultKind(key))
+	c.callCounts[countKey]++
+	attempt := c.callCounts[countKey]
+
+	for _, f := range c.faults {
+		if err, inject := f(op, key, attempt); inject {
+			return err
+		}
+	}
+	return nil
+}
+
+// faultKind returns the Kind a fault spec's "Nth call" counter is keyed
+// on. Most Kinds share the model.ResourceKey Go type, so keying on the
+// type alone (as opposed to the Kind it carries) would count calls to
+// unrelated Kinds together; fall back to the type for Key implementations
+// that don't carry a Kind.
+func faultKind(key model.Key) string {
+	if rk, ok := key.(model.ResourceKey); ok {
+		return rk.Kind
+	}
+	return fmt.Sprintf("%T", key)
+}
+
+func (c *Client) Create(ctx context.Context, d *model.KVPair) (*model.KVPair, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.checkFault("Create", d.Key); err != nil {
+		return nil, err
+	}
+
+	k := d.Key.String()
+	if _, ok := c.objects[k]; ok {
+		return nil, cerrors.ErrorResourceAlreadyExists{Identifier: d.Key}
+	}
+
+	c.resourceVersion++
+	stored := copyKVP(d)
+	stored.Revision = c.revisionString()
+	c.objects[k] = stored
+
+	c.notify(api.WatchAdded, nil, stored)
+	return copyKVP(stored), nil
+}
+
+func (c *Client) Update(ctx context.Context, d *model.KVPair) (*model.KVPair, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.checkFault("Update", d.Key); err != nil {
+		return nil, err
+	}
+
+	k := d.Key.String()
+	existing, ok := c.objects[k]
+	if !ok {
+		return nil, cerrors.ErrorResourceDoesNotExist{Identifier: d.Key}
+	}
+	if d.Revision != "" && d.Revision != existing.Revision {
+		return nil, cerrors.ErrorResourceUpdateConflict{Identifier: d.Key}
+	}
+
+	c.resourceVersion++
+	stored := copyKVP(d)
+	stored.Revision = c.revisionString()
+	c.objects[k] = stored
+
+	c.notify(api.WatchModified, existing, stored)
+	return copyKVP(stored), nil
+}
+
+func (c *Client) Apply(ctx context.Context, kvp *model.KVPair) (*model.KVPair, error) {
+	created, err := c.Create(ctx, &model.KVPair{Key: kvp.Key, Value: kvp.Value})
+	if err == nil {
+		return created, nil
+	}
+	if _, ok := err.(cerrors.ErrorResourceAlreadyExists); !ok {
+		return nil, err
+	}
+	return c.Update(ctx, kvp)
+}
+
+func (c *Client) DeleteKVP(ctx context.Context, kvp *model.KVPair) (*model.KVPair, error) {
+	return c.Delete(ctx, kvp.Key, kvp.Revision)
+}
+
+func (c *Client) Delete(ctx context.Context, k model.Key, revision string) (*model.KVPair, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.checkFault("Delete", k); err != nil {
+		return nil, err
+	}
+
+	key := k.String()
+	existing, ok := c.objects[key]
+	if !ok {
+		return nil, cerrors.ErrorResourceDoesNotExist{Identifier: k}
+	}
+	if revision != "" && revision != existing.Revision {
+		return nil, cerrors.ErrorResourceUpdateConflict{Identifier: k}
+	}
+
+	delete(c.objects, key)
+	c.notify(api.WatchDeleted, existing, nil)
+	return copyKVP(existing), nil
+}
+
+func (c *Client) Get(ctx context.Context, k model.Key, revision string) (*model.KVPair, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.checkFault("Get", k); err != nil {
+		return nil, err
+	}
+
+	existing, ok := c.objects[k.String()]
+	if !ok {
+		return nil, cerrors.ErrorResourceDoesNotExist{Identifier: k}
+	}
+	return copyKVP(existing), nil
+}
+
+func (c *Client) List(ctx context.Context, l model.ListInterface, revision string) (*model.KVPairList, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var kvps []*model.KVPair
+	for _, kvp := range c.objects {
+		if listMatches(l, kvp.Key) {
+			kvps = append(kvps, copyKVP(kvp))
+		}
+	}
+	return &model.KVPairList{KVPairs: kvps, Revision: c.revisionString()}, nil
+}
+
+func (c *Client) Watch(ctx context.Context, l model.ListInterface, options api.WatchOptions) (api.WatchInterface, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	w := &watcher{
+		list:    l,
+		results: make(chan api.WatchEvent, 16),
+		done:    make(chan struct{}),
+	}
+	c.watchers = append(c.watchers, w)
+
+	go func() {
+		<-ctx.Done()
+		w.Stop()
+	}()
+
+	return w, nil
+}
+
+func (c *Client) EnsureInitialized() error { return nil }
+func (c *Client) Clean() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.objects = make(map[string]*model.KVPair)
+	return nil
+}
+func (c *Client) Close() error { return nil }
+
+// listMatches reports whether key falls within l. A model.ResourceKey is
+// matched on Kind (and on Namespace/Name when l sets them); other keys are
+// matched by round-tripping through their default path.
+func listMatches(l model.ListInterface, key model.Key) bool {
+	if rl, ok := l.(model.ResourceListOptions); ok {
+		rk, ok := key.(model.ResourceKey)
+		return ok && rk.Kind == rl.Kind &&
+			(rl.Namespace == "" || rl.Namespace == rk.Namespace) &&
+			(rl.Name == "" || rl.Name == rk.Name)
+	}
+	path, err := model.KeyToDefaultPath(key)
+	return err == nil && l.KeyFromDefaultPath(path) != nil
+}
+
+// notify delivers an event to every live watcher whose list matches key.
+// If a watcher's buffer is full the event is dropped for that watcher
+// rather than blocking Create/Update/Delete.
+func (c *Client) notify(eventType api.WatchEventType, old, new *model.KVPair) {
+	var key model.Key
+	if new != nil {
+		key = new.Key
+	} else {
+		key = old.Key
+	}
+
+	live := c.watchers[:0]
+	for _, w := range c.watchers {
+		if w.HasTerminated() {
+			continue
+		}
+		if listMatches(w.list, key) {
+			select {
+			case w.results <- api.WatchEvent{Type: eventType, Old: old, New: new}:
+			default:
+			}
+		}
+		live = append(live, w)
+	}
+	c.watchers = live
+}
+
+// watcher is the api.WatchInterface returned by Client.Watch.
+type watcher struct {
+	list    model.ListInterface
+	results chan api.WatchEvent
+	done    chan struct{}
+	once    sync.Once
+}
+
+func (w *watcher) Stop() {
+	w.once.Do(func() { close(w.done) })
+}
+
+func (w *watcher) ResultChan() <-chan api.WatchEvent {
+	return w.results
+}
+
+func (w *watcher) HasTerminated() bool {
+	select {
+	case <-w.done:
+		return true
+	default:
+		return false
+	}
+}
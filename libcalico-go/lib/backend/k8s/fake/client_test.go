@@ -0,0 +1,133 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fake
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+func testKey(name string) model.ResourceKey {
+	return model.ResourceKey{Kind: "TestKind", Name: name}
+}
+
+func testList() model.ResourceListOptions {
+	return model.ResourceListOptions{Kind: "TestKind"}
+}
+
+func TestCreateGetDelete(t *testing.T) {
+	c := NewClient()
+	ctx := context.Background()
+
+	key := testKey("a")
+	created, err := c.Create(ctx, &model.KVPair{Key: key, Value: "v1"})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	if created.Revision == "" {
+		t.Error("expected Create to assign a Revision")
+	}
+
+	got, err := c.Get(ctx, key, "")
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if got.Value != "v1" {
+		t.Errorf("expected value v1, got %v", got.Value)
+	}
+
+	if _, err := c.Delete(ctx, key, ""); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	if _, err := c.Get(ctx, key, ""); err == nil {
+		t.Error("expected Get after Delete to fail")
+	}
+}
+
+func TestFaultInjection(t *testing.T) {
+	c := NewClient()
+	ctx := context.Background()
+
+	wantErr := errors.New("injected failure")
+	c.InjectFault(func(op string, k model.Key, attempt int) (error, bool) {
+		if op == "Create" && attempt == 2 {
+			return wantErr, true
+		}
+		return nil, false
+	})
+
+	if _, err := c.Create(ctx, &model.KVPair{Key: testKey("a"), Value: "v1"}); err != nil {
+		t.Fatalf("first Create should succeed, got: %v", err)
+	}
+	if _, err := c.Create(ctx, &model.KVPair{Key: testKey("b"), Value: "v2"}); err != wantErr {
+		t.Fatalf("expected injected error on second Create call, got: %v", err)
+	}
+}
+
+func TestFaultInjectionPerKind(t *testing.T) {
+	c := NewClient()
+	ctx := context.Background()
+
+	wantErr := errors.New("injected failure")
+	c.InjectFault(func(op string, k model.Key, attempt int) (error, bool) {
+		if op == "Create" && k.(model.ResourceKey).Kind == "KindB" && attempt == 2 {
+			return wantErr, true
+		}
+		return nil, false
+	})
+
+	// Two Creates of KindA must not count towards KindB's "2nd call" fault.
+	if _, err := c.Create(ctx, &model.KVPair{Key: model.ResourceKey{Kind: "KindA", Name: "a1"}, Value: "v1"}); err != nil {
+		t.Fatalf("KindA Create 1 should succeed, got: %v", err)
+	}
+	if _, err := c.Create(ctx, &model.KVPair{Key: model.ResourceKey{Kind: "KindA", Name: "a2"}, Value: "v2"}); err != nil {
+		t.Fatalf("KindA Create 2 should succeed, got: %v", err)
+	}
+
+	if _, err := c.Create(ctx, &model.KVPair{Key: model.ResourceKey{Kind: "KindB", Name: "b1"}, Value: "v1"}); err != nil {
+		t.Fatalf("KindB Create 1 should succeed, got: %v", err)
+	}
+	if _, err := c.Create(ctx, &model.KVPair{Key: model.ResourceKey{Kind: "KindB", Name: "b2"}, Value: "v2"}); err != wantErr {
+		t.Fatalf("expected injected error on KindB Create 2, got: %v", err)
+	}
+}
+
+func TestWatchEmitsCreate(t *testing.T) {
+	c := NewClient()
+	ctx := context.Background()
+
+	w, err := c.Watch(ctx, testList(), api.WatchOptions{})
+	if err != nil {
+		t.Fatalf("Watch returned error: %v", err)
+	}
+
+	key := testKey("d")
+	if _, err := c.Create(ctx, &model.KVPair{Key: key, Value: "v1"}); err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+
+	select {
+	case e := <-w.ResultChan():
+		if e.New == nil || e.New.Key.String() != key.String() {
+			t.Errorf("unexpected watch event: %+v", e)
+		}
+	default:
+		t.Error("expected a watch event to be available")
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	stdnet "net"
+	"testing"
+)
+
+func TestAddOffsetInCIDR(t *testing.T) {
+	_, ipNet, err := stdnet.ParseCIDR("10.0.0.0/24")
+	if err != nil {
+		t.Fatalf("ParseCIDR returned error: %v", err)
+	}
+
+	got, err := addOffsetInCIDR(ipNet.IP, ipNet.Mask, 1)
+	if err != nil {
+		t.Fatalf("addOffsetInCIDR returned error: %v", err)
+	}
+	if got.String() != "10.0.0.1" {
+		t.Errorf("expected 10.0.0.1, got %s", got)
+	}
+
+	got, err = addOffsetInCIDR(ipNet.IP, ipNet.Mask, 10)
+	if err != nil {
+		t.Fatalf("addOffsetInCIDR returned error: %v", err)
+	}
+	if got.String() != "10.0.0.10" {
+		t.Errorf("expected 10.0.0.10, got %s", got)
+	}
+
+	if _, err := addOffsetInCIDR(ipNet.IP, ipNet.Mask, 256); err == nil {
+		t.Error("expected an error for an offset outside the /24 CIDR")
+	}
+}
+
+func TestAddOffsetInCIDRIPv6(t *testing.T) {
+	_, ipNet, err := stdnet.ParseCIDR("fd00::/120")
+	if err != nil {
+		t.Fatalf("ParseCIDR returned error: %v", err)
+	}
+
+	got, err := addOffsetInCIDR(ipNet.IP.To16(), ipNet.Mask, 1)
+	if err != nil {
+		t.Fatalf("addOffsetInCIDR returned error: %v", err)
+	}
+	if got.String() != "fd00::1" {
+		t.Errorf("expected fd00::1, got %s", got)
+	}
+}
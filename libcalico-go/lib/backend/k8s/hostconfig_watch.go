@@ -0,0 +1,224 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+// hostConfigResyncPeriod is how often the Node informer backing
+// watchHostConfig re-delivers every cached Node to handleUpdate; the
+// lastSent diff means such a resync only emits events for entries whose
+// derived value changed.
+const hostConfigResyncPeriod = 5 * time.Minute
+
+// watchHostConfig serves Watch(HostConfigListOptions) from a dedicated Node
+// informer rather than (unsupportedly) falling through getResourceClientFromList,
+// translating Node add/update/delete into api.WatchEvents carrying the same
+// derived KVPair shape nodeHostConfig produces.
+//
+// Resuming from a prior revision isn't supported: the informer always
+// establishes its own Node list on start (rather than a Watch seeded by
+// options.Revision), and a reconnecting caller will instead see a synthetic
+// Added for every entry still in scope, courtesy of
+// hostConfigWatcher.lastSent starting empty. options.Revision is accepted
+// for interface compatibility with api.Client.Watch but otherwise ignored.
+func (c *KubeClient) watchHostConfig(ctx context.Context, l model.HostConfigListOptions, options api.WatchOptions) (api.WatchInterface, error) {
+	if options.Revision != "" {
+		log.WithField("revision", options.Revision).Debug("watchHostConfig does not support resuming from a revision; performing a full relist")
+	}
+
+	w := &hostConfigWatcher{
+		list:     l,
+		results:  make(chan api.WatchEvent, 16),
+		done:     make(chan struct{}),
+		lastSent: make(map[string]map[string]string),
+	}
+
+	informer := cache.NewSharedIndexInformer(
+		cache.NewListWatchFromClient(c.ClientSet.CoreV1().RESTClient(), "nodes", "", fields.Everything()),
+		&v1.Node{},
+		hostConfigResyncPeriod,
+		cache.Indexers{},
+	)
+
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if node, ok := obj.(*v1.Node); ok {
+				w.handleUpdate(c, node)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if node, ok := newObj.(*v1.Node); ok {
+				w.handleUpdate(c, node)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			node, ok := obj.(*v1.Node)
+			if !ok {
+				if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+					node, ok = tombstone.Obj.(*v1.Node)
+					if !ok {
+						return
+					}
+				} else {
+					return
+				}
+			}
+			w.handleDelete(node.Name)
+		},
+	})
+
+	informerCtx, cancel := context.WithCancel(ctx)
+	go informer.Run(informerCtx.Done())
+
+	go func() {
+		<-w.done
+		cancel()
+	}()
+
+	return w, nil
+}
+
+// hostConfigWatcher is the api.WatchInterface backing watchHostConfig.
+type hostConfigWatcher struct {
+	list    model.HostConfigListOptions
+	results chan api.WatchEvent
+	done    chan struct{}
+	once    sync.Once
+
+	mu sync.Mutex
+	// lastSent holds the last value sent for each (node, HostConfig name),
+	// so resyncs only emit events for entries that actually changed, and
+	// entries that disappeared (e.g. PodCIDR unassigned) produce a
+	// synthetic Deleted event instead of going silent.
+	lastSent map[string]map[string]string
+}
+
+// matchesScope reports whether node is in scope for w.list's "all nodes" or
+// "single hostname" form.
+func (w *hostConfigWatcher) matchesScope(nodeName string) bool {
+	return w.list.Hostname == "" || w.list.Hostname == nodeName
+}
+
+func (w *hostConfigWatcher) handleUpdate(c *KubeClient, node *v1.Node) {
+	if !w.matchesScope(node.Name) {
+		return
+	}
+
+	kvps, err := c.nodeHostConfig(node)
+	if err != nil {
+		log.WithError(err).WithField("node", node.Name).Warn("Failed to derive HostConfig for node, skipping")
+		return
+	}
+
+	current := make(map[string]string, len(kvps))
+	for _, kvp := range kvps {
+		key := kvp.Key.(model.HostConfigKey)
+		if w.list.Name != "" && key.Name != w.list.Name {
+			continue
+		}
+		current[key.Name] = kvp.Value.(string)
+	}
+
+	w.mu.Lock()
+	previous := w.lastSent[node.Name]
+	w.lastSent[node.Name] = current
+	w.mu.Unlock()
+
+	for name, value := range current {
+		if previous[name] == value {
+			continue
+		}
+		eventType := api.WatchAdded
+		if _, existed := previous[name]; existed {
+			eventType = api.WatchModified
+		}
+		w.emit(api.WatchEvent{
+			Type: eventType,
+			New: &model.KVPair{
+				Key:   model.HostConfigKey{Hostname: node.Name, Name: name},
+				Value: value,
+			},
+		})
+	}
+	for name, value := range previous {
+		if _, stillPresent := current[name]; stillPresent {
+			continue
+		}
+		w.emit(api.WatchEvent{
+			Type: api.WatchDeleted,
+			Old: &model.KVPair{
+				Key:   model.HostConfigKey{Hostname: node.Name, Name: name},
+				Value: value,
+			},
+		})
+	}
+}
+
+func (w *hostConfigWatcher) handleDelete(nodeName string) {
+	if !w.matchesScope(nodeName) {
+		return
+	}
+
+	w.mu.Lock()
+	previous := w.lastSent[nodeName]
+	delete(w.lastSent, nodeName)
+	w.mu.Unlock()
+
+	for name, value := range previous {
+		w.emit(api.WatchEvent{
+			Type: api.WatchDeleted,
+			Old: &model.KVPair{
+				Key:   model.HostConfigKey{Hostname: nodeName, Name: name},
+				Value: value,
+			},
+		})
+	}
+}
+
+func (w *hostConfigWatcher) emit(e api.WatchEvent) {
+	select {
+	case w.results <- e:
+	case <-w.done:
+	}
+}
+
+func (w *hostConfigWatcher) Stop() {
+	w.once.Do(func() { close(w.done) })
+}
+
+func (w *hostConfigWatcher) ResultChan() <-chan api.WatchEvent {
+	return w.results
+}
+
+func (w *hostConfigWatcher) HasTerminated() bool {
+	select {
+	case <-w.done:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"testing"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+func newHostConfigWatcher(l model.HostConfigListOptions) *hostConfigWatcher {
+	return &hostConfigWatcher{
+		list:     l,
+		results:  make(chan api.WatchEvent, 16),
+		done:     make(chan struct{}),
+		lastSent: make(map[string]map[string]string),
+	}
+}
+
+func drainEvents(t *testing.T, w *hostConfigWatcher, n int) []api.WatchEvent {
+	t.Helper()
+	var events []api.WatchEvent
+	for i := 0; i < n; i++ {
+		select {
+		case e := <-w.results:
+			events = append(events, e)
+		default:
+			t.Fatalf("expected %d events, only got %d", n, len(events))
+		}
+	}
+	select {
+	case e := <-w.results:
+		t.Fatalf("expected exactly %d events, got an extra one: %+v", n, e)
+	default:
+	}
+	return events
+}
+
+func TestHostConfigWatcherHandleUpdateEmitsAddedThenModified(t *testing.T) {
+	c := &KubeClient{}
+	w := newHostConfigWatcher(model.HostConfigListOptions{})
+
+	node := linuxNode("node-1", "10.0.0.0/24")
+	w.handleUpdate(c, node)
+
+	added := drainEvents(t, w, 2)
+	for _, e := range added {
+		if e.Type != api.WatchAdded {
+			t.Errorf("expected Added on first observation, got %v", e.Type)
+		}
+	}
+
+	// Re-running handleUpdate with the same PodCIDR must not re-emit
+	// anything, since nothing changed.
+	w.handleUpdate(c, node)
+	drainEvents(t, w, 0)
+
+	// A PodCIDR change must emit Modified for the entries whose value
+	// changed.
+	node.Spec.PodCIDR = "10.0.1.0/24"
+	w.handleUpdate(c, node)
+	modified := drainEvents(t, w, 2)
+	for _, e := range modified {
+		if e.Type != api.WatchModified {
+			t.Errorf("expected Modified after a PodCIDR change, got %v", e.Type)
+		}
+	}
+}
+
+func TestHostConfigWatcherHandleDeleteEmitsSyntheticDeletes(t *testing.T) {
+	c := &KubeClient{}
+	w := newHostConfigWatcher(model.HostConfigListOptions{})
+
+	node := linuxNode("node-1", "10.0.0.0/24")
+	w.handleUpdate(c, node)
+	drainEvents(t, w, 2)
+
+	w.handleDelete(node.Name)
+	deleted := drainEvents(t, w, 2)
+	for _, e := range deleted {
+		if e.Type != api.WatchDeleted {
+			t.Errorf("expected Deleted after node removal, got %v", e.Type)
+		}
+	}
+
+	// lastSent must have been cleared, so a second delete is a no-op.
+	w.handleDelete(node.Name)
+	drainEvents(t, w, 0)
+}
+
+func TestHostConfigWatcherMatchesScope(t *testing.T) {
+	c := &KubeClient{}
+
+	all := newHostConfigWatcher(model.HostConfigListOptions{})
+	if !all.matchesScope("any-node") {
+		t.Error("expected an empty Hostname scope to match every node")
+	}
+
+	scoped := newHostConfigWatcher(model.HostConfigListOptions{Hostname: "node-1"})
+	if !scoped.matchesScope("node-1") {
+		t.Error("expected a Hostname-scoped watcher to match its own node")
+	}
+	if scoped.matchesScope("node-2") {
+		t.Error("expected a Hostname-scoped watcher to not match another node")
+	}
+
+	// A node outside scope must be ignored entirely, not just filtered
+	// after computing its HostConfig.
+	scoped.handleUpdate(c, linuxNode("node-2", "10.0.0.0/24"))
+	drainEvents(t, scoped, 0)
+}
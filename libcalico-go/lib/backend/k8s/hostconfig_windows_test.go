@@ -0,0 +1,125 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+func linuxNode(name, podCIDR string) *v1.Node {
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{"kubernetes.io/os": "linux"},
+		},
+		Spec: v1.NodeSpec{PodCIDR: podCIDR},
+	}
+}
+
+func windowsNode(name, podCIDR string) *v1.Node {
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{"kubernetes.io/os": "windows"},
+		},
+		Spec: v1.NodeSpec{PodCIDR: podCIDR},
+	}
+}
+
+func hostConfigNames(kvps []*model.KVPair) []string {
+	var names []string
+	for _, kvp := range kvps {
+		names = append(names, kvp.Key.(model.HostConfigKey).Name)
+	}
+	return names
+}
+
+func TestIsWindowsNode(t *testing.T) {
+	if isWindowsNode(linuxNode("linux-1", "10.0.0.0/24")) {
+		t.Error("expected linux node to not be detected as Windows")
+	}
+	if !isWindowsNode(windowsNode("win-1", "10.0.1.0/24")) {
+		t.Error("expected windows node to be detected as Windows")
+	}
+
+	// Falls back to NodeInfo.OperatingSystem when the label is absent.
+	n := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "win-2"},
+		Status:     v1.NodeStatus{NodeInfo: v1.NodeSystemInfo{OperatingSystem: "windows"}},
+	}
+	if !isWindowsNode(n) {
+		t.Error("expected NodeInfo.OperatingSystem fallback to detect Windows")
+	}
+}
+
+func TestNodeHostConfigMixedLinuxWindows(t *testing.T) {
+	linux := linuxNode("linux-1", "10.0.0.0/24")
+	win := windowsNode("win-1", "10.0.1.0/24")
+
+	c := &KubeClient{windowsTunnelMode: WindowsTunnelModeNone}
+
+	linuxKvps, err := c.nodeHostConfig(linux)
+	if err != nil {
+		t.Fatalf("nodeHostConfig(linux) returned error: %v", err)
+	}
+	names := hostConfigNames(linuxKvps)
+	if !containsAll(names, hostConfigIPIPTunnelAddr, hostConfigVXLANTunnelAddr) {
+		t.Errorf("expected linux node to get both IPIP and VXLAN tunnel addrs, got %v", names)
+	}
+
+	winKvps, err := c.nodeHostConfig(win)
+	if err != nil {
+		t.Fatalf("nodeHostConfig(windows) returned error: %v", err)
+	}
+	if len(winKvps) != 0 {
+		t.Errorf("expected WindowsTunnelModeNone to suppress all tunnel addrs, got %v", hostConfigNames(winKvps))
+	}
+
+	c.windowsTunnelMode = WindowsTunnelModeVXLAN
+	winKvps, err = c.nodeHostConfig(win)
+	if err != nil {
+		t.Fatalf("nodeHostConfig(windows, vxlan mode) returned error: %v", err)
+	}
+	names = hostConfigNames(winKvps)
+	if containsAny(names, hostConfigIPIPTunnelAddr) {
+		t.Errorf("expected no IPIP tunnel addr for Windows node, got %v", names)
+	}
+	if !containsAll(names, hostConfigVXLANTunnelAddr) {
+		t.Errorf("expected VXLAN tunnel addr for Windows node in vxlan mode, got %v", names)
+	}
+}
+
+func containsAll(haystack []string, wants ...string) bool {
+	for _, want := range wants {
+		if !containsAny(haystack, want) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsAny(haystack []string, want string) bool {
+	for _, h := range haystack {
+		if h == want {
+			return true
+		}
+	}
+	return false
+}
@@ -17,6 +17,8 @@ package k8s
 import (
 	"context"
 	"fmt"
+	"math/big"
+	stdnet "net"
 	"path/filepath"
 	"reflect"
 	"strings"
@@ -28,11 +30,16 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/client-go/discovery"
+	cacheddiscovery "k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth" // Import all auth providers.
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	adminpolicyclient "sigs.k8s.io/network-policy-api/pkg/client/clientset/versioned/typed/apis/v1alpha1"
 
 	"github.com/projectcalico/calico/libcalico-go/lib/apiconfig"
@@ -76,6 +83,50 @@ type KubeClient struct {
 
 	// Non v3 resource clients keyed off List Type.
 	clientsByListType map[reflect.Type]resources.K8sResourceClient
+
+	// dynamicClient and restMapper back RegisterCustomResourceKind,
+	// letting callers register additional out-of-tree Kinds at runtime
+	// without a bespoke resources.K8sResourceClient implementation.
+	dynamicClient dynamic.Interface
+	restMapper    *restmapper.DeferredDiscoveryRESTMapper
+
+	// fieldManager and applyMode configure how Apply behaves: ServerSide
+	// Apply (PATCH with application/apply-patch+yaml) vs the legacy
+	// Create-then-Update ClientSide behavior. forceApplyOnConflict, when
+	// set, reassigns ownership of conflicting fields to fieldManager
+	// instead of Apply returning cerrors.ErrorFieldManagerConflict.
+	fieldManager         string
+	applyMode            apiconfig.ApplyMode
+	forceApplyOnConflict bool
+
+	// wireguardAnnotationPrefix is the node annotation prefix calico/node
+	// writes its WireGuard public key and interface address under, e.g.
+	// "<prefix>PublicKey" and "<prefix>InterfaceAddr". listHostConfig
+	// reads it back out to synthesize WireguardPublicKey/
+	// WireguardInterfaceAddr HostConfig entries.
+	wireguardAnnotationPrefix string
+
+	// windowsTunnelMode is one of the WindowsTunnelMode* constants,
+	// controlling how nodeHostConfig handles Windows nodes.
+	windowsTunnelMode string
+
+	// podCIDRTunnelOffset is added to a PodCIDR's network address to
+	// derive its tunnel address, so it can be aligned with a
+	// differently-configured host-local IPAM rangeStart. NewKubeClient
+	// resolves this to defaultPodCIDRTunnelOffset when
+	// CalicoAPIConfigSpec.K8sPodCIDRTunnelOffset is unset (nil), so a
+	// zero value here always means an operator explicitly chose 0.
+	podCIDRTunnelOffset int
+}
+
+// isWindowsNode reports whether n is a Windows node, via the standard
+// kubernetes.io/os label (preferred - it's set by kubelet before the node
+// is schedulable) falling back to NodeInfo.OperatingSystem.
+func isWindowsNode(n *v1.Node) bool {
+	if os := n.Labels["kubernetes.io/os"]; os != "" {
+		return os == "windows"
+	}
+	return strings.EqualFold(n.Status.NodeInfo.OperatingSystem, "windows")
 }
 
 func NewKubeClient(ca *apiconfig.CalicoAPIConfigSpec) (api.Client, error) {
@@ -94,14 +145,48 @@ func NewKubeClient(ca *apiconfig.CalicoAPIConfigSpec) (api.Client, error) {
 		return nil, fmt.Errorf("Failed to build K8S Admin Network Policy client: %v", err)
 	}
 
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to build dynamic client: %v", err)
+	}
+
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to build discovery client: %v", err)
+	}
+	restMapper := restmapper.NewDeferredDiscoveryRESTMapper(cacheddiscovery.NewMemCacheClient(discoveryClient))
+
+	wireguardAnnotationPrefix := ca.K8sWireguardAnnotationPrefix
+	if wireguardAnnotationPrefix == "" {
+		wireguardAnnotationPrefix = defaultWireguardAnnotationPrefix
+	}
+
+	windowsTunnelMode := ca.K8sWindowsTunnelMode
+	if windowsTunnelMode == "" {
+		windowsTunnelMode = WindowsTunnelModeNone
+	}
+
+	podCIDRTunnelOffset := defaultPodCIDRTunnelOffset
+	if ca.K8sPodCIDRTunnelOffset != nil {
+		podCIDRTunnelOffset = *ca.K8sPodCIDRTunnelOffset
+	}
+
 	kubeClient := &KubeClient{
-		ClientSet:             cs,
-		crdClientV1:           crdClientV1,
-		k8sAdminPolicyClient:  k8sAdminPolicyClient,
-		disableNodePoll:       ca.K8sDisableNodePoll,
-		clientsByResourceKind: make(map[string]resources.K8sResourceClient),
-		clientsByKeyType:      make(map[reflect.Type]resources.K8sResourceClient),
-		clientsByListType:     make(map[reflect.Type]resources.K8sResourceClient),
+		ClientSet:                 cs,
+		crdClientV1:               crdClientV1,
+		k8sAdminPolicyClient:      k8sAdminPolicyClient,
+		disableNodePoll:           ca.K8sDisableNodePoll,
+		clientsByResourceKind:     make(map[string]resources.K8sResourceClient),
+		clientsByKeyType:          make(map[reflect.Type]resources.K8sResourceClient),
+		clientsByListType:         make(map[reflect.Type]resources.K8sResourceClient),
+		dynamicClient:             dynamicClient,
+		restMapper:                restMapper,
+		fieldManager:              ca.K8sFieldManager,
+		applyMode:                 ca.K8sApplyMode,
+		forceApplyOnConflict:      ca.K8sApplyForceConflicts,
+		wireguardAnnotationPrefix: wireguardAnnotationPrefix,
+		windowsTunnelMode:         windowsTunnelMode,
+		podCIDRTunnelOffset:       podCIDRTunnelOffset,
 	}
 
 	// Create the Calico sub-clients and register them.
@@ -368,6 +453,45 @@ func CreateKubernetesClientset(ca *apiconfig.CalicoAPIConfigSpec) (*rest.Config,
 		configOverrides.ClusterInfo.InsecureSkipTLSVerify = true
 	}
 
+	// Impersonation lets the datastore driver act as another user, e.g. when a
+	// multi-tenant proxy in front of the API server needs to attribute writes
+	// to the tenant that triggered them rather than to the driver's own
+	// identity.
+	if ca.K8sImpersonateUserName != "" {
+		configOverrides.AuthInfo.Impersonate = ca.K8sImpersonateUserName
+		configOverrides.AuthInfo.ImpersonateUID = ca.K8sImpersonateUserUID
+		configOverrides.AuthInfo.ImpersonateGroups = ca.K8sImpersonateGroups
+		if len(ca.K8sImpersonateUserExtra) != 0 {
+			configOverrides.AuthInfo.ImpersonateUserExtra = ca.K8sImpersonateUserExtra
+		}
+	}
+
+	// An exec credential plugin (e.g. a cloud provider's IAM authenticator, or
+	// a workload-identity token exchanger) mints and refreshes its own
+	// short-lived tokens. client-go only runs it when no static bearer token,
+	// basic auth or client certificate is configured (via these overrides or
+	// the kubeconfig); otherwise the static credentials are used.
+	if ca.K8sExecCredential != nil {
+		configOverrides.AuthInfo.Exec = &clientcmdapi.ExecConfig{
+			Command:            ca.K8sExecCredential.Command,
+			Args:               ca.K8sExecCredential.Args,
+			APIVersion:         ca.K8sExecCredential.APIVersion,
+			InstallHint:        ca.K8sExecCredential.InstallHint,
+			ProvideClusterInfo: ca.K8sExecCredential.ProvideClusterInfo,
+		}
+		for _, e := range ca.K8sExecCredential.Env {
+			configOverrides.AuthInfo.Exec.Env = append(configOverrides.AuthInfo.Exec.Env,
+				clientcmdapi.ExecEnvVar{Name: e.Name, Value: e.Value})
+		}
+	}
+
+	// A bearer token file (e.g. a projected Kubernetes service account token
+	// used for workload identity) is periodically re-read by client-go, so
+	// unlike K8sAPIToken it picks up rotations without restarting.
+	if ca.K8sAPITokenFile != "" {
+		configOverrides.AuthInfo.TokenFile = ca.K8sAPITokenFile
+	}
+
 	// A kubeconfig file was provided.  Use it to load a config, passing through
 	// any overrides.
 	var config *rest.Config
@@ -423,6 +547,22 @@ func (c *KubeClient) GetResourceClientFromResourceKind(kind string) resources.K8
 	return c.clientsByResourceKind[kind]
 }
 
+// RegisterCustomResourceKind registers a resources.NewDynamicClient for
+// gvk under model.ResourceKey{Kind: gvk.Kind}, letting operators and tests
+// add out-of-tree CRDs to the client without a bespoke
+// resources.K8sResourceClient implementation. converter only needs to
+// handle generic unstructured marshalling (see
+// resources.UnstructuredConverter) - out-of-tree CRDs registered this way
+// have no Calico-specific conversion semantics to apply.
+func (c *KubeClient) RegisterCustomResourceKind(gvk schema.GroupVersionKind, converter resources.UnstructuredConverter) {
+	c.registerResourceClient(
+		resourceKeyType,
+		resourceListType,
+		gvk.Kind,
+		resources.NewDynamicClient(c.dynamicClient, c.restMapper, gvk, converter),
+	)
+}
+
 // getResourceClientFromKey returns the appropriate resource client for the key.
 func (c *KubeClient) getResourceClientFromKey(key model.Key) resources.K8sResourceClient {
 	kt := reflect.TypeOf(key)
@@ -603,6 +743,35 @@ func (c *KubeClient) Apply(ctx context.Context, kvp *model.KVPair) (*model.KVPai
 	})
 	logContext.Debug("Apply Kubernetes resource")
 
+	if c.applyMode == apiconfig.ApplyModeServerSide {
+		client := c.getResourceClientFromKey(kvp.Key)
+		if client == nil {
+			return nil, cerrors.ErrorOperationNotSupported{
+				Identifier: kvp.Key,
+				Operation:  "Apply",
+			}
+		}
+		applier, ok := client.(resources.ServerSideApplier)
+		if !ok {
+			// Only resources.DynamicClient implements ServerSideApplier
+			// today (registered via RegisterCustomResourceKind); the
+			// built-in resource kinds don't yet. Silently falling back
+			// to Create/Update here would make K8sApplyMode:
+			// ApplyModeServerSide a no-op for those kinds without any
+			// indication to the operator who configured it, so fail
+			// loudly instead.
+			logContext.Debug("Resource client does not support Server-Side Apply")
+			return nil, cerrors.ErrorOperationNotSupported{
+				Identifier: kvp.Key,
+				Operation:  "ServerSideApply",
+			}
+		}
+		return applier.ServerSideApply(ctx, kvp, resources.ApplyOptions{
+			FieldManager: c.fieldManager,
+			Force:        c.forceApplyOnConflict,
+		})
+	}
+
 	// Attempt to Create and do an Update if the resource already exists.
 	// We only log debug here since the Create and Update will also log.
 	// Can't set Revision while creating a resource.
@@ -686,6 +855,9 @@ func (c *KubeClient) List(ctx context.Context, l model.ListInterface, revision s
 // Watch starts a watch on a particular resource type.
 func (c *KubeClient) Watch(ctx context.Context, l model.ListInterface, options api.WatchOptions) (api.WatchInterface, error) {
 	log.Debugf("Performing 'Watch' for %+v %v", l, reflect.TypeOf(l))
+	if hcl, ok := l.(model.HostConfigListOptions); ok {
+		return c.watchHostConfig(ctx, hcl, options)
+	}
 	client := c.getResourceClientFromList(l)
 	if client == nil {
 		log.Debug("Attempt to 'Watch' using kubernetes backend is not supported.")
@@ -701,11 +873,57 @@ func (c *KubeClient) getReadyStatus(ctx context.Context, k model.ReadyFlagKey, r
 	return &model.KVPair{Key: k, Value: true}, nil
 }
 
+// Derived HostConfig names. IPIP and VXLAN tunnel addresses are computed
+// from the node's PodCIDR(s); the WireGuard entries are copied verbatim
+// from node annotations written by calico/node.
+const (
+	hostConfigIPIPTunnelAddr     = "IpInIpTunnelAddr"
+	hostConfigIPIPTunnelAddrV6   = "IPv6IPIPTunnelAddr"
+	hostConfigVXLANTunnelAddr    = "VXLANTunnelAddr"
+	hostConfigVXLANTunnelAddrV6  = "IPv6VXLANTunnelAddr"
+	hostConfigWireguardPublicKey = "WireguardPublicKey"
+	hostConfigWireguardIfaceAddr = "WireguardInterfaceAddr"
+)
+
+// WindowsTunnelMode values control what, if anything, nodeHostConfig
+// substitutes for the (Windows-unsupported) IPIP tunnel address on Windows
+// nodes.
+const (
+	// WindowsTunnelModeNone suppresses tunnel address derivation entirely
+	// for Windows nodes. This is the default.
+	WindowsTunnelModeNone = "none"
+	// WindowsTunnelModeVXLAN emits only the VXLAN tunnel address for
+	// Windows nodes, since IPIP isn't supported on Windows.
+	WindowsTunnelModeVXLAN = "vxlan"
+)
+
+// defaultPodCIDRTunnelOffset is added to a PodCIDR's network address to
+// derive its tunnel address when the KubeClient isn't configured with an
+// explicit PodCIDRTunnelOffset, matching the historical ".1" behavior.
+const defaultPodCIDRTunnelOffset = 1
+
+// defaultWireguardAnnotationPrefix is used to read the WireGuard public key
+// and interface address off a Node's annotations when the KubeClient isn't
+// configured with an explicit WireguardAnnotationPrefix.
+const defaultWireguardAnnotationPrefix = "projectcalico.org/Wireguard"
+
+// derivedHostConfigNames are the HostConfig names listHostConfig knows how
+// to derive. HostConfigListOptions.Name short-circuits to just these so the
+// handler doesn't do a node listing for unrelated config.
+var derivedHostConfigNames = map[string]bool{
+	hostConfigIPIPTunnelAddr:     true,
+	hostConfigIPIPTunnelAddrV6:   true,
+	hostConfigVXLANTunnelAddr:    true,
+	hostConfigVXLANTunnelAddrV6:  true,
+	hostConfigWireguardPublicKey: true,
+	hostConfigWireguardIfaceAddr: true,
+}
+
 func (c *KubeClient) listHostConfig(ctx context.Context, l model.HostConfigListOptions, revision string) (*model.KVPairList, error) {
 	kvps := []*model.KVPair{}
 
 	// Short circuit if they aren't asking for information we can provide.
-	if l.Name != "" && l.Name != "IpInIpTunnelAddr" {
+	if l.Name != "" && !derivedHostConfigNames[l.Name] {
 		return &model.KVPairList{
 			KVPairs:  kvps,
 			Revision: revision,
@@ -720,12 +938,12 @@ func (c *KubeClient) listHostConfig(ctx context.Context, l model.HostConfigListO
 		}
 
 		for _, node := range nodes.Items {
-			kvp, err := getTunIp(&node)
-			if err != nil || kvp == nil {
+			nodeKvps, err := c.nodeHostConfig(&node)
+			if err != nil {
 				continue
 			}
 
-			kvps = append(kvps, kvp)
+			kvps = append(kvps, filterHostConfigName(nodeKvps, l.Name)...)
 		}
 	} else {
 		node, err := c.ClientSet.CoreV1().Nodes().Get(ctx, l.Hostname, metav1.GetOptions{})
@@ -733,15 +951,15 @@ func (c *KubeClient) listHostConfig(ctx context.Context, l model.HostConfigListO
 			return nil, resources.K8sErrorToCalico(err, l)
 		}
 
-		kvp, err := getTunIp(node)
-		if err != nil || kvp == nil {
+		nodeKvps, err := c.nodeHostConfig(node)
+		if err != nil {
 			return &model.KVPairList{
 				KVPairs:  []*model.KVPair{},
 				Revision: revision,
 			}, nil
 		}
 
-		kvps = append(kvps, kvp)
+		kvps = append(kvps, filterHostConfigName(nodeKvps, l.Name)...)
 	}
 
 	return &model.KVPairList{
@@ -750,29 +968,146 @@ func (c *KubeClient) listHostConfig(ctx context.Context, l model.HostConfigListO
 	}, nil
 }
 
-func getTunIp(n *v1.Node) (*model.KVPair, error) {
-	if n.Spec.PodCIDR == "" {
+// filterHostConfigName returns the subset of kvps whose HostConfigKey.Name
+// matches name, or all of kvps if name is empty.
+func filterHostConfigName(kvps []*model.KVPair, name string) []*model.KVPair {
+	if name == "" {
+		return kvps
+	}
+	var out []*model.KVPair
+	for _, kvp := range kvps {
+		if kvp.Key.(model.HostConfigKey).Name == name {
+			out = append(out, kvp)
+		}
+	}
+	return out
+}
+
+// nodeHostConfig derives the IPIP tunnel address(es) for n from its pod CIDR(s),
+// one per address family, handling both the legacy single-stack
+// Spec.PodCIDR and the dual-stack Spec.PodCIDRs list. The tunnel address is
+// the CIDR's network address plus c.podCIDRTunnelOffset (1 by default, to
+// match the historical "first usable address" behavior), computed via
+// addOffsetInCIDR so it works the same way for IPv4 and IPv6 and so an
+// offset that doesn't fit in the CIDR is caught rather than silently
+// wrapping.
+//
+// nodeHostConfig also emits the equivalent VXLAN tunnel address entries
+// (derived the same way, since either encap mode may be in use), and the
+// WireGuard public key/interface address copied from n's annotations, if
+// present.
+func (c *KubeClient) nodeHostConfig(n *v1.Node) ([]*model.KVPair, error) {
+	podCIDRs := n.Spec.PodCIDRs
+	if len(podCIDRs) == 0 && n.Spec.PodCIDR != "" {
+		podCIDRs = []string{n.Spec.PodCIDR}
+	}
+
+	// c.podCIDRTunnelOffset is resolved once in NewKubeClient (defaulting
+	// a nil K8sPodCIDRTunnelOffset to defaultPodCIDRTunnelOffset), so an
+	// operator-chosen 0 is used as-is here rather than re-defaulted.
+	offset := c.podCIDRTunnelOffset
+
+	var kvps []*model.KVPair
+	if len(podCIDRs) == 0 {
 		log.Warnf("Node %s does not have podCIDR for HostConfig", n.Name)
-		return nil, nil
 	}
+	for _, cidr := range podCIDRs {
+		ip, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			log.Warnf("Invalid podCIDR for HostConfig: %s, %s", n.Name, cidr)
+			return nil, err
+		}
 
-	ip, _, err := net.ParseCIDR(n.Spec.PodCIDR)
-	if err != nil {
-		log.Warnf("Invalid podCIDR for HostConfig: %s, %s", n.Name, n.Spec.PodCIDR)
-		return nil, err
+		ipipName, vxlanName := hostConfigIPIPTunnelAddr, hostConfigVXLANTunnelAddr
+		addr := ip.To4()
+		if addr == nil {
+			ipipName, vxlanName = hostConfigIPIPTunnelAddrV6, hostConfigVXLANTunnelAddrV6
+			addr = ip.To16()
+		}
+		tunAddr, err := addOffsetInCIDR(addr, ipNet.Mask, offset)
+		if err != nil {
+			log.WithError(err).Warnf("Cannot derive tunnel address for node %s from podCIDR %s, skipping", n.Name, cidr)
+			continue
+		}
+		tunIP := tunAddr.String()
+
+		// IPIP isn't supported on Windows, so never emit an IPIP tunnel
+		// address for a Windows node. Depending on WindowsTunnelMode,
+		// either substitute the VXLAN address in its place or omit both.
+		if isWindowsNode(n) {
+			if c.windowsTunnelMode != WindowsTunnelModeVXLAN {
+				continue
+			}
+			kvps = append(kvps, &model.KVPair{
+				Key:   model.HostConfigKey{Hostname: n.Name, Name: vxlanName},
+				Value: tunIP,
+			})
+			continue
+		}
+
+		kvps = append(kvps,
+			&model.KVPair{
+				Key:   model.HostConfigKey{Hostname: n.Name, Name: ipipName},
+				Value: tunIP,
+			},
+			&model.KVPair{
+				Key:   model.HostConfigKey{Hostname: n.Name, Name: vxlanName},
+				Value: tunIP,
+			},
+		)
 	}
-	// We need to get the IP for the podCIDR and increment it to the
-	// first IP in the CIDR.
-	tunIp := ip.To4()
-	tunIp[3]++
 
-	kvp := &model.KVPair{
-		Key: model.HostConfigKey{
-			Hostname: n.Name,
-			Name:     "IpInIpTunnelAddr",
-		},
-		Value: tunIp.String(),
+	kvps = append(kvps, c.nodeWireguardConfig(n)...)
+
+	return kvps, nil
+}
+
+// nodeWireguardConfig reads the WireGuard public key and interface address
+// calico/node wrote to n's annotations (under wireguardAnnotationPrefix)
+// and surfaces them as HostConfig KVPairs, so Felix can source them from
+// the KDD the same way it would from etcd.
+func (c *KubeClient) nodeWireguardConfig(n *v1.Node) []*model.KVPair {
+	prefix := c.wireguardAnnotationPrefix
+	if prefix == "" {
+		prefix = defaultWireguardAnnotationPrefix
+	}
+
+	var kvps []*model.KVPair
+	for suffix, name := range map[string]string{
+		"PublicKey":     hostConfigWireguardPublicKey,
+		"InterfaceAddr": hostConfigWireguardIfaceAddr,
+	} {
+		value, ok := n.Annotations[prefix+suffix]
+		if !ok || value == "" {
+			continue
+		}
+		kvps = append(kvps, &model.KVPair{
+			Key:   model.HostConfigKey{Hostname: n.Name, Name: name},
+			Value: value,
+		})
+	}
+	return kvps
+}
+
+// addOffsetInCIDR returns base (the network address of a CIDR) plus offset,
+// computed with arbitrary-precision arithmetic so it works uniformly for
+// 4-byte and 16-byte addresses, and returns an error instead of silently
+// wrapping if offset doesn't fit within the address space described by
+// mask.
+func addOffsetInCIDR(base stdnet.IP, mask stdnet.IPMask, offset int) (stdnet.IP, error) {
+	ones, bits := mask.Size()
+	hostBits := uint(bits - ones)
+	cidrSize := new(big.Int).Lsh(big.NewInt(1), hostBits)
+
+	baseInt := new(big.Int).SetBytes(base)
+	upper := new(big.Int).Add(baseInt, cidrSize)
+	sum := new(big.Int).Add(baseInt, big.NewInt(int64(offset)))
+	if sum.Cmp(baseInt) < 0 || sum.Cmp(upper) >= 0 {
+		return nil, fmt.Errorf("offset %d does not fit within a /%d CIDR", offset, ones)
 	}
 
-	return kvp, nil
+	raw := sum.Bytes()
+	out := make(stdnet.IP, len(base))
+	copy(out[len(out)-len(raw):], raw)
+	return out, nil
 }
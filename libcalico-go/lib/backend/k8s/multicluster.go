@@ -0,0 +1,405 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/apiconfig"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	cerrors "github.com/projectcalico/calico/libcalico-go/lib/errors"
+)
+
+// clusterScopedKey is implemented by model.Key types that carry an
+// explicit cluster selector (e.g. ClusterKey). Keys that don't implement
+// it can't be routed to a single member cluster.
+type clusterScopedKey interface {
+	ClusterName() string
+}
+
+// ClusterKey wraps a model.Key with the name of the member cluster it
+// belongs to, so MultiClusterClient.routeSingle can route
+// Create/Update/Apply/Delete/Get calls to the right member KubeClient.
+// Callers that talk to a MultiClusterClient must use this (or another
+// clusterScopedKey) in place of the bare model.Key they'd pass to a
+// single-cluster api.Client.
+type ClusterKey struct {
+	model.Key
+	Cluster string
+}
+
+// ClusterName returns the member cluster k belongs to.
+func (k ClusterKey) ClusterName() string {
+	return k.Cluster
+}
+
+// MultiClusterClient is an api.Client that fans requests out across N
+// member KubeClients, one per registered cluster, so callers can work with
+// a federated view of the same registered Kinds without knowing how many
+// clusters are behind it.
+type MultiClusterClient struct {
+	mu      sync.RWMutex
+	members map[string]api.Client
+	health  map[string]*clusterHealth
+}
+
+// clusterHealth tracks consecutive failures for a single member cluster.
+// List and Watch record their per-cluster results here but do not consult
+// it: every member is always included in fanned-out calls.
+type clusterHealth struct {
+	consecutiveFailures int
+	lastErr             error
+}
+
+// NewMultiClusterClient builds a MultiClusterClient from a set of
+// already-constructed per-cluster KubeClients, keyed by cluster name.
+func NewMultiClusterClient(clients map[string]api.Client) *MultiClusterClient {
+	m := &MultiClusterClient{
+		members: make(map[string]api.Client, len(clients)),
+		health:  make(map[string]*clusterHealth, len(clients)),
+	}
+	for name, c := range clients {
+		m.members[name] = c
+		m.health[name] = &clusterHealth{}
+	}
+	return m
+}
+
+// NewMultiClusterClientFromConfigs constructs one KubeClient per entry in
+// configs (keyed by cluster name) and wraps them in a MultiClusterClient.
+func NewMultiClusterClientFromConfigs(configs map[string]*apiconfig.CalicoAPIConfigSpec) (*MultiClusterClient, error) {
+	clients := make(map[string]api.Client, len(configs))
+	for name, ca := range configs {
+		c, err := NewKubeClient(ca)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build k8s client for cluster %q: %w", name, err)
+		}
+		clients[name] = c
+	}
+	return NewMultiClusterClient(clients), nil
+}
+
+func (m *MultiClusterClient) member(name string) (api.Client, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	c, ok := m.members[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown cluster %q", name)
+	}
+	return c, nil
+}
+
+func (m *MultiClusterClient) recordResult(name string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	h, ok := m.health[name]
+	if !ok {
+		h = &clusterHealth{}
+		m.health[name] = h
+	}
+	if err != nil {
+		h.consecutiveFailures++
+		h.lastErr = err
+	} else {
+		h.consecutiveFailures = 0
+		h.lastErr = nil
+	}
+}
+
+// splitClusterRevision extracts cluster's revision out of a
+// comma-separated, cluster-qualified revision of the form
+// "<cluster1>:<rv1>,<cluster2>:<rv2>" produced by mergeRevisions. If
+// revision doesn't carry an entry for cluster (including when it's ""),
+// splitClusterRevision returns "", so that member starts from scratch.
+func splitClusterRevision(cluster, revision string) string {
+	for _, part := range strings.Split(revision, ",") {
+		name, rv, ok := strings.Cut(part, ":")
+		if ok && name == cluster {
+			return rv
+		}
+	}
+	return ""
+}
+
+// mergeRevisions combines the per-cluster revisions from revs (keyed by
+// cluster name) into a single opaque revision string that List/Watch can
+// round-trip and later split back with splitClusterRevision.
+func mergeRevisions(revs map[string]string) string {
+	parts := make([]string, 0, len(revs))
+	for cluster, rv := range revs {
+		parts = append(parts, cluster+":"+rv)
+	}
+	return strings.Join(parts, ",")
+}
+
+func (m *MultiClusterClient) routeSingle(key model.Key) (api.Client, error) {
+	scoped, ok := key.(clusterScopedKey)
+	if !ok {
+		return nil, fmt.Errorf("key %s does not carry a cluster selector", key)
+	}
+	return m.member(scoped.ClusterName())
+}
+
+func (m *MultiClusterClient) Create(ctx context.Context, d *model.KVPair) (*model.KVPair, error) {
+	c, err := m.routeSingle(d.Key)
+	if err != nil {
+		return nil, err
+	}
+	return c.Create(ctx, d)
+}
+
+func (m *MultiClusterClient) Update(ctx context.Context, d *model.KVPair) (*model.KVPair, error) {
+	c, err := m.routeSingle(d.Key)
+	if err != nil {
+		return nil, err
+	}
+	return c.Update(ctx, d)
+}
+
+func (m *MultiClusterClient) Apply(ctx context.Context, d *model.KVPair) (*model.KVPair, error) {
+	c, err := m.routeSingle(d.Key)
+	if err != nil {
+		return nil, err
+	}
+	return c.Apply(ctx, d)
+}
+
+func (m *MultiClusterClient) DeleteKVP(ctx context.Context, d *model.KVPair) (*model.KVPair, error) {
+	c, err := m.routeSingle(d.Key)
+	if err != nil {
+		return nil, err
+	}
+	return c.DeleteKVP(ctx, d)
+}
+
+func (m *MultiClusterClient) Delete(ctx context.Context, k model.Key, revision string) (*model.KVPair, error) {
+	c, err := m.routeSingle(k)
+	if err != nil {
+		return nil, err
+	}
+	scoped := k.(clusterScopedKey)
+	return c.Delete(ctx, k, splitClusterRevision(scoped.ClusterName(), revision))
+}
+
+func (m *MultiClusterClient) Get(ctx context.Context, k model.Key, revision string) (*model.KVPair, error) {
+	c, err := m.routeSingle(k)
+	if err != nil {
+		return nil, err
+	}
+	scoped := k.(clusterScopedKey)
+	return c.Get(ctx, k, splitClusterRevision(scoped.ClusterName(), revision))
+}
+
+// List fans l out to every member cluster, merging the results. If some
+// clusters fail, the successful clusters' results are still returned along
+// with a cerrors.ErrorPartialFailure naming the clusters that failed,
+// rather than discarding everything.
+func (m *MultiClusterClient) List(ctx context.Context, l model.ListInterface, revision string) (*model.KVPairList, error) {
+	m.mu.RLock()
+	members := make(map[string]api.Client, len(m.members))
+	for name, c := range m.members {
+		members[name] = c
+	}
+	m.mu.RUnlock()
+
+	type result struct {
+		cluster string
+		list    *model.KVPairList
+		err     error
+	}
+	results := make(chan result, len(members))
+
+	var wg sync.WaitGroup
+	for name, c := range members {
+		wg.Add(1)
+		go func(name string, c api.Client) {
+			defer wg.Done()
+			rv := splitClusterRevision(name, revision)
+			list, err := c.List(ctx, l, rv)
+			m.recordResult(name, err)
+			results <- result{cluster: name, list: list, err: err}
+		}(name, c)
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var merged []*model.KVPair
+	revs := make(map[string]string, len(members))
+	var failed []string
+	for r := range results {
+		if r.err != nil {
+			log.WithError(r.err).WithField("cluster", r.cluster).Warn("Failed to list resources from cluster, continuing with remaining clusters")
+			failed = append(failed, r.cluster)
+			continue
+		}
+		merged = append(merged, r.list.KVPairs...)
+		revs[r.cluster] = r.list.Revision
+	}
+
+	out := &model.KVPairList{KVPairs: merged, Revision: mergeRevisions(revs)}
+	if len(failed) > 0 {
+		return out, cerrors.ErrorPartialFailure{Clusters: failed}
+	}
+	return out, nil
+}
+
+// Watch fans out to every member cluster and merges their event streams
+// into a single api.WatchInterface. Each emitted event's revision is
+// rewritten to the "<cluster>:<rv>" form so a caller that persists the
+// merged revision and reconnects later can split it back per cluster.
+func (m *MultiClusterClient) Watch(ctx context.Context, l model.ListInterface, options api.WatchOptions) (api.WatchInterface, error) {
+	m.mu.RLock()
+	members := make(map[string]api.Client, len(m.members))
+	for name, c := range m.members {
+		members[name] = c
+	}
+	m.mu.RUnlock()
+
+	mw := &multiClusterWatcher{
+		results: make(chan api.WatchEvent, 16*len(members)),
+		done:    make(chan struct{}),
+	}
+
+	for name, c := range members {
+		rv := splitClusterRevision(name, options.Revision)
+		w, err := c.Watch(ctx, l, api.WatchOptions{Revision: rv})
+		if err != nil {
+			m.recordResult(name, err)
+			log.WithError(err).WithField("cluster", name).Warn("Failed to start watch on cluster, it will be missing from the merged stream")
+			continue
+		}
+		mw.add(name, w)
+	}
+
+	return mw, nil
+}
+
+func (m *MultiClusterClient) EnsureInitialized() error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var errs []error
+	for name, c := range m.members {
+		if err := c.EnsureInitialized(); err != nil {
+			errs = append(errs, fmt.Errorf("cluster %q: %w", name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%d of %d clusters failed to initialize: %v", len(errs), len(m.members), errs)
+	}
+	return nil
+}
+
+func (m *MultiClusterClient) Clean() error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var errs []error
+	for name, c := range m.members {
+		if err := c.Clean(); err != nil {
+			errs = append(errs, fmt.Errorf("cluster %q: %w", name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%d of %d clusters failed to clean: %v", len(errs), len(m.members), errs)
+	}
+	return nil
+}
+
+func (m *MultiClusterClient) Close() error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var errs []error
+	for name, c := range m.members {
+		if err := c.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("cluster %q: %w", name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%d of %d clusters failed to close: %v", len(errs), len(m.members), errs)
+	}
+	return nil
+}
+
+// multiClusterWatcher merges the watch.Interface-style event channels from
+// each member cluster's api.WatchInterface into one channel.
+type multiClusterWatcher struct {
+	mu       sync.Mutex
+	upstream []api.WatchInterface
+	results  chan api.WatchEvent
+	done     chan struct{}
+	once     sync.Once
+}
+
+func (w *multiClusterWatcher) add(cluster string, upstream api.WatchInterface) {
+	w.mu.Lock()
+	w.upstream = append(w.upstream, upstream)
+	w.mu.Unlock()
+
+	go func() {
+		for e := range upstream.ResultChan() {
+			e.New = withClusterRevision(cluster, e.New)
+			e.Old = withClusterRevision(cluster, e.Old)
+			select {
+			case w.results <- e:
+			case <-w.done:
+				return
+			}
+		}
+	}()
+}
+
+// withClusterRevision returns a shallow copy of kvp with its Revision
+// rewritten to the "<cluster>:<rv>" form mergeRevisions/splitClusterRevision
+// expect, so a caller that persists the merged Watch revision and
+// reconnects later can resume each member cluster from the right point.
+func withClusterRevision(cluster string, kvp *model.KVPair) *model.KVPair {
+	if kvp == nil {
+		return nil
+	}
+	out := *kvp
+	out.Revision = mergeRevisions(map[string]string{cluster: kvp.Revision})
+	return &out
+}
+
+func (w *multiClusterWatcher) Stop() {
+	w.once.Do(func() {
+		close(w.done)
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		for _, u := range w.upstream {
+			u.Stop()
+		}
+	})
+}
+
+func (w *multiClusterWatcher) ResultChan() <-chan api.WatchEvent {
+	return w.results
+}
+
+func (w *multiClusterWatcher) HasTerminated() bool {
+	select {
+	case <-w.done:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/k8s/fake"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+func TestMergeAndSplitClusterRevision(t *testing.T) {
+	merged := mergeRevisions(map[string]string{"east": "10", "west": "20"})
+
+	if got := splitClusterRevision("east", merged); got != "10" {
+		t.Errorf("expected east's revision to be 10, got %q", got)
+	}
+	if got := splitClusterRevision("west", merged); got != "20" {
+		t.Errorf("expected west's revision to be 20, got %q", got)
+	}
+}
+
+func TestSplitClusterRevisionUnknownOrEmpty(t *testing.T) {
+	if got := splitClusterRevision("east", ""); got != "" {
+		t.Errorf("expected empty revision for empty input, got %q", got)
+	}
+	if got := splitClusterRevision("north", "east:10,west:20"); got != "" {
+		t.Errorf("expected empty revision for a cluster absent from the merged string, got %q", got)
+	}
+}
+
+func TestRouteSingleUsesClusterKey(t *testing.T) {
+	east := fake.NewClient()
+	west := fake.NewClient()
+	m := NewMultiClusterClient(map[string]api.Client{"east": east, "west": west})
+
+	key := ClusterKey{Key: model.ResourceKey{Kind: "TestKind", Name: "a"}, Cluster: "west"}
+	if _, err := m.Create(context.Background(), &model.KVPair{Key: key, Value: "v1"}); err != nil {
+		t.Fatalf("unexpected error creating via MultiClusterClient: %v", err)
+	}
+
+	if _, err := west.Get(context.Background(), model.ResourceKey{Kind: "TestKind", Name: "a"}, ""); err != nil {
+		t.Errorf("expected the object to have been routed to the west cluster: %v", err)
+	}
+	if _, err := east.Get(context.Background(), model.ResourceKey{Kind: "TestKind", Name: "a"}, ""); err == nil {
+		t.Error("expected the object to not exist in the east cluster")
+	}
+}
+
+func TestRouteSingleUnknownCluster(t *testing.T) {
+	m := NewMultiClusterClient(map[string]api.Client{"east": fake.NewClient()})
+
+	key := ClusterKey{Key: model.ResourceKey{Kind: "TestKind", Name: "a"}, Cluster: "north"}
+	if _, err := m.Get(context.Background(), key, ""); err == nil {
+		t.Error("expected an error routing to an unregistered cluster")
+	}
+}
+
+func TestWithClusterRevisionRewritesRevisionForRouting(t *testing.T) {
+	kvp := &model.KVPair{Key: model.ResourceKey{Kind: "TestKind", Name: "a"}, Revision: "10"}
+
+	rewritten := withClusterRevision("east", kvp)
+	if rewritten == kvp {
+		t.Error("expected withClusterRevision to return a copy, not the original KVPair")
+	}
+	if got := splitClusterRevision("east", rewritten.Revision); got != "10" {
+		t.Errorf("expected the rewritten revision to round-trip through splitClusterRevision, got %q", got)
+	}
+	if kvp.Revision != "10" {
+		t.Errorf("expected the original KVPair to be unmodified, got Revision %q", kvp.Revision)
+	}
+
+	if withClusterRevision("east", nil) != nil {
+		t.Error("expected withClusterRevision(nil) to return nil")
+	}
+}
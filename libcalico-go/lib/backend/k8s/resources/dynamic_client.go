@@ -0,0 +1,308 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+// UnstructuredConverter converts between the unstructured Kubernetes
+// representation of a CRD and Calico's model.KVPair. It's deliberately
+// narrower than conversion.Converter (the Kind-specific converter used by
+// the generated per-resource clients, e.g. Pod->WorkloadEndpoint): a CRD
+// served through DynamicClient has no Calico-specific semantics to apply
+// beyond generic unstructured marshalling, so it only needs these four
+// methods rather than a full conversion.Converter implementation.
+type UnstructuredConverter interface {
+	ToUnstructured(kvp *model.KVPair) (u *unstructured.Unstructured, namespace string, name string, err error)
+	FromUnstructured(u *unstructured.Unstructured) (*model.KVPair, error)
+	KeyToNamespaceName(key model.Key) (namespace string, name string, err error)
+	ListToNamespace(list model.ListInterface) (namespace string, err error)
+}
+
+// DynamicClient is a K8sResourceClient that serves an arbitrary GVK through
+// the Kubernetes dynamic client, rather than requiring a bespoke,
+// generated client per Kind. It's intended for CRDs that don't need any
+// Calico-specific conversion beyond what Converter provides - for anything
+// with richer semantics (e.g. the IPAM resources), a dedicated client is
+// still the right choice.
+type DynamicClient struct {
+	client     dynamic.Interface
+	restMapper *restmapper.DeferredDiscoveryRESTMapper
+	gvk        schema.GroupVersionKind
+	converter  UnstructuredConverter
+}
+
+// NewDynamicClient returns a K8sResourceClient that serves gvk through the
+// dynamic client, converting between the unstructured Kubernetes
+// representation and Calico's model.KVPair via converter.
+func NewDynamicClient(
+	client dynamic.Interface,
+	mapper *restmapper.DeferredDiscoveryRESTMapper,
+	gvk schema.GroupVersionKind,
+	converter UnstructuredConverter,
+) *DynamicClient {
+	return &DynamicClient{
+		client:     client,
+		restMapper: mapper,
+		gvk:        gvk,
+		converter:  converter,
+	}
+}
+
+// resource resolves d.gvk to a GroupVersionResource (and whether it's
+// namespaced) via the RESTMapper, refreshing the mapper's discovery cache
+// once and retrying if the mapping isn't found - this lets newly-installed
+// CRDs become usable without restarting the process.
+func (d *DynamicClient) resource() (schema.GroupVersionResource, bool, error) {
+	mapping, err := d.restMapper.RESTMapping(d.gvk.GroupKind(), d.gvk.Version)
+	if err != nil {
+		if !meta.IsNoMatchError(err) {
+			return schema.GroupVersionResource{}, false, err
+		}
+		log.WithField("gvk", d.gvk).Debug("No REST mapping found, refreshing discovery cache")
+		d.restMapper.Reset()
+		mapping, err = d.restMapper.RESTMapping(d.gvk.GroupKind(), d.gvk.Version)
+		if err != nil {
+			return schema.GroupVersionResource{}, false, err
+		}
+	}
+	return mapping.Resource, mapping.Scope.Name() == meta.RESTScopeNameNamespace, nil
+}
+
+func (d *DynamicClient) resourceInterface(namespace string) (dynamic.ResourceInterface, error) {
+	gvr, namespaced, err := d.resource()
+	if err != nil {
+		return nil, err
+	}
+	if namespaced {
+		return d.client.Resource(gvr).Namespace(namespace), nil
+	}
+	return d.client.Resource(gvr), nil
+}
+
+func (d *DynamicClient) Create(ctx context.Context, kvp *model.KVPair) (*model.KVPair, error) {
+	u, namespace, name, err := d.converter.ToUnstructured(kvp)
+	if err != nil {
+		return nil, err
+	}
+	ri, err := d.resourceInterface(namespace)
+	if err != nil {
+		return nil, err
+	}
+	u.SetName(name)
+	created, err := ri.Create(ctx, u, metav1.CreateOptions{})
+	if err != nil {
+		return nil, K8sErrorToCalico(err, kvp.Key)
+	}
+	return d.converter.FromUnstructured(created)
+}
+
+func (d *DynamicClient) Update(ctx context.Context, kvp *model.KVPair) (*model.KVPair, error) {
+	u, namespace, name, err := d.converter.ToUnstructured(kvp)
+	if err != nil {
+		return nil, err
+	}
+	ri, err := d.resourceInterface(namespace)
+	if err != nil {
+		return nil, err
+	}
+	u.SetName(name)
+	u.SetResourceVersion(kvp.Revision)
+	updated, err := ri.Update(ctx, u, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, K8sErrorToCalico(err, kvp.Key)
+	}
+	return d.converter.FromUnstructured(updated)
+}
+
+func (d *DynamicClient) DeleteKVP(ctx context.Context, kvp *model.KVPair) (*model.KVPair, error) {
+	return d.Delete(ctx, kvp.Key, kvp.Revision, nil)
+}
+
+func (d *DynamicClient) Delete(ctx context.Context, key model.Key, revision string, uid *types.UID) (*model.KVPair, error) {
+	namespace, name, err := d.converter.KeyToNamespaceName(key)
+	if err != nil {
+		return nil, err
+	}
+	ri, err := d.resourceInterface(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, getErr := ri.Get(ctx, name, metav1.GetOptions{})
+	var prev *model.KVPair
+	if getErr == nil {
+		prev, _ = d.converter.FromUnstructured(existing)
+	}
+
+	opts := metav1.DeleteOptions{}
+	if revision != "" {
+		opts.Preconditions = &metav1.Preconditions{ResourceVersion: &revision}
+	}
+	if uid != nil {
+		if opts.Preconditions == nil {
+			opts.Preconditions = &metav1.Preconditions{}
+		}
+		opts.Preconditions.UID = uid
+	}
+	if err := ri.Delete(ctx, name, opts); err != nil {
+		return nil, K8sErrorToCalico(err, key)
+	}
+	return prev, nil
+}
+
+func (d *DynamicClient) Get(ctx context.Context, key model.Key, revision string) (*model.KVPair, error) {
+	namespace, name, err := d.converter.KeyToNamespaceName(key)
+	if err != nil {
+		return nil, err
+	}
+	ri, err := d.resourceInterface(namespace)
+	if err != nil {
+		return nil, err
+	}
+	u, err := ri.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, K8sErrorToCalico(err, key)
+	}
+	return d.converter.FromUnstructured(u)
+}
+
+func (d *DynamicClient) List(ctx context.Context, list model.ListInterface, revision string) (*model.KVPairList, error) {
+	namespace, err := d.converter.ListToNamespace(list)
+	if err != nil {
+		return nil, err
+	}
+	ri, err := d.resourceInterface(namespace)
+	if err != nil {
+		return nil, err
+	}
+	items, err := ri.List(ctx, metav1.ListOptions{ResourceVersion: revision})
+	if err != nil {
+		return nil, K8sErrorToCalico(err, list)
+	}
+
+	kvps := make([]*model.KVPair, 0, len(items.Items))
+	for i := range items.Items {
+		kvp, err := d.converter.FromUnstructured(&items.Items[i])
+		if err != nil {
+			log.WithError(err).WithField("gvk", d.gvk).Warn("Failed to convert unstructured resource, skipping")
+			continue
+		}
+		kvps = append(kvps, kvp)
+	}
+	return &model.KVPairList{KVPairs: kvps, Revision: items.GetResourceVersion()}, nil
+}
+
+func (d *DynamicClient) Watch(ctx context.Context, list model.ListInterface, options api.WatchOptions) (api.WatchInterface, error) {
+	namespace, err := d.converter.ListToNamespace(list)
+	if err != nil {
+		return nil, err
+	}
+	ri, err := d.resourceInterface(namespace)
+	if err != nil {
+		return nil, err
+	}
+	k8sWatch, err := ri.Watch(ctx, metav1.ListOptions{ResourceVersion: options.Revision, Watch: true})
+	if err != nil {
+		return nil, K8sErrorToCalico(err, list)
+	}
+	return newDynamicWatcher(d.converter, k8sWatch), nil
+}
+
+func (d *DynamicClient) EnsureInitialized() error {
+	return nil
+}
+
+// dynamicWatcher adapts a raw watch.Interface over unstructured objects
+// into an api.WatchInterface of model.KVPairs, converting each event with
+// the DynamicClient's Converter.
+type dynamicWatcher struct {
+	converter UnstructuredConverter
+	upstream  watch.Interface
+	results   chan api.WatchEvent
+}
+
+func newDynamicWatcher(converter UnstructuredConverter, upstream watch.Interface) *dynamicWatcher {
+	w := &dynamicWatcher{
+		converter: converter,
+		upstream:  upstream,
+		results:   make(chan api.WatchEvent),
+	}
+	go w.run()
+	return w
+}
+
+func (w *dynamicWatcher) run() {
+	defer close(w.results)
+	for e := range w.upstream.ResultChan() {
+		if e.Type == watch.Error {
+			w.results <- api.WatchEvent{Type: api.WatchError, Error: apierrors.FromObject(e.Object)}
+			continue
+		}
+
+		u, ok := e.Object.(*unstructured.Unstructured)
+		if !ok {
+			w.results <- api.WatchEvent{Type: api.WatchError, Error: fmt.Errorf("unexpected watch object type %T", e.Object)}
+			continue
+		}
+		kvp, err := w.converter.FromUnstructured(u)
+		if err != nil {
+			w.results <- api.WatchEvent{Type: api.WatchError, Error: err}
+			continue
+		}
+
+		switch e.Type {
+		case watch.Added:
+			w.results <- api.WatchEvent{Type: api.WatchAdded, New: kvp}
+		case watch.Modified:
+			w.results <- api.WatchEvent{Type: api.WatchModified, New: kvp}
+		case watch.Deleted:
+			w.results <- api.WatchEvent{Type: api.WatchDeleted, Old: kvp}
+		}
+	}
+}
+
+func (w *dynamicWatcher) Stop() {
+	w.upstream.Stop()
+}
+
+func (w *dynamicWatcher) ResultChan() <-chan api.WatchEvent {
+	return w.results
+}
+
+func (w *dynamicWatcher) HasTerminated() bool {
+	select {
+	case _, ok := <-w.results:
+		return !ok
+	default:
+		return false
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	cerrors "github.com/projectcalico/calico/libcalico-go/lib/errors"
+)
+
+// ApplyOptions controls how ServerSideApply patches a resource.
+type ApplyOptions struct {
+	// FieldManager identifies the caller to the API server for the
+	// purposes of field ownership tracking.
+	FieldManager string
+
+	// Force reassigns ownership of conflicting fields to FieldManager
+	// instead of returning an ApplyConflict error.
+	Force bool
+}
+
+// ServerSideApplier is implemented by K8sResourceClients that support
+// Server-Side Apply, in addition to the Create-then-Update semantics of
+// the base K8sResourceClient interface. Only resources.DynamicClient
+// implements this today; when apiconfig.ApplyModeServerSide is
+// configured, KubeClient.Apply returns cerrors.ErrorOperationNotSupported
+// for any resource kind whose client doesn't implement it, rather than
+// silently falling back to Create-then-Update.
+type ServerSideApplier interface {
+	ServerSideApply(ctx context.Context, kvp *model.KVPair, opts ApplyOptions) (*model.KVPair, error)
+}
+
+// ServerSideApply issues a PATCH of type application/apply-patch+yaml
+// against d's GVR, converting a field-manager conflict from the API
+// server into cerrors.ErrorFieldManagerConflict so callers can surface
+// the offending manager(s).
+func (d *DynamicClient) ServerSideApply(ctx context.Context, kvp *model.KVPair, opts ApplyOptions) (*model.KVPair, error) {
+	u, namespace, name, err := d.converter.ToUnstructured(kvp)
+	if err != nil {
+		return nil, err
+	}
+	u.SetName(name)
+
+	ri, err := d.resourceInterface(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := u.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s for server-side apply: %w", name, err)
+	}
+
+	force := opts.Force
+	applied, err := ri.Patch(ctx, name, types.ApplyPatchType, data, metav1.PatchOptions{
+		FieldManager: opts.FieldManager,
+		Force:        &force,
+	})
+	if err != nil {
+		if conflict, ok := asFieldManagerConflict(name, err); ok {
+			return nil, conflict
+		}
+		return nil, K8sErrorToCalico(err, kvp.Key)
+	}
+	return d.converter.FromUnstructured(applied)
+}
+
+// asFieldManagerConflict reports whether err is the API server's
+// "conflict" response to a non-forced Server-Side Apply, and if so
+// translates it into a cerrors.ErrorFieldManagerConflict naming the
+// managers that own the conflicting fields.
+func asFieldManagerConflict(identifier string, err error) (cerrors.ErrorFieldManagerConflict, bool) {
+	status, ok := err.(apierrors.APIStatus)
+	if !ok || status.Status().Reason != metav1.StatusReasonConflict {
+		return cerrors.ErrorFieldManagerConflict{}, false
+	}
+
+	// Each conflict is reported as a cause whose Field is the conflicting
+	// field path and whose Message names the owning manager, e.g.
+	// `conflict with "kubectl" using v1`.
+	var managers []string
+	seen := map[string]bool{}
+	if details := status.Status().Details; details != nil {
+		for _, cause := range details.Causes {
+			if cause.Type != metav1.CauseTypeFieldManagerConflict {
+				continue
+			}
+			if _, rest, ok := strings.Cut(cause.Message, `"`); ok {
+				if manager, _, ok := strings.Cut(rest, `"`); ok && !seen[manager] {
+					seen[manager] = true
+					managers = append(managers, manager)
+				}
+			}
+		}
+	}
+	return cerrors.ErrorFieldManagerConflict{Identifier: identifier, Managers: managers}, true
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2016-2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package errors defines the sentinel error types returned by Calico's
+// backend datastore drivers, so callers can type-assert on them rather
+// than matching on error strings.
+package errors
+
+import "fmt"
+
+// ErrorResourceAlreadyExists is returned when a Create is attempted for a
+// resource that already exists in the datastore.
+type ErrorResourceAlreadyExists struct {
+	Identifier interface{}
+}
+
+func (e ErrorResourceAlreadyExists) Error() string {
+	return fmt.Sprintf("resource already exists: %v", e.Identifier)
+}
+
+// ErrorResourceDoesNotExist is returned when an operation that requires an
+// existing resource (Update, Delete, Get) targets a key that isn't present
+// in the datastore.
+type ErrorResourceDoesNotExist struct {
+	Identifier interface{}
+}
+
+func (e ErrorResourceDoesNotExist) Error() string {
+	return fmt.Sprintf("resource does not exist: %v", e.Identifier)
+}
+
+// ErrorResourceUpdateConflict is returned when an Update or Delete supplies
+// a Revision that no longer matches the stored resource's current Revision.
+type ErrorResourceUpdateConflict struct {
+	Identifier interface{}
+}
+
+func (e ErrorResourceUpdateConflict) Error() string {
+	return fmt.Sprintf("update conflict: resource %v has been modified since the given Revision was read", e.Identifier)
+}
+
+// ErrorOperationNotSupported is returned when an operation isn't supported
+// by the backend driver for the given resource.
+type ErrorOperationNotSupported struct {
+	Identifier interface{}
+	Operation  string
+}
+
+func (e ErrorOperationNotSupported) Error() string {
+	return fmt.Sprintf("operation %s is not supported on %v", e.Operation, e.Identifier)
+}
+
+// ErrorFieldManagerConflict is returned when a Server-Side Apply PATCH is
+// rejected by the API server because the write would reassign fields
+// owned by another field manager, and Force wasn't set to reclaim them.
+type ErrorFieldManagerConflict struct {
+	Identifier interface{}
+
+	// Managers names the field managers that own the conflicting fields,
+	// when the API server's response includes that detail.
+	Managers []string
+}
+
+func (e ErrorFieldManagerConflict) Error() string {
+	return fmt.Sprintf("field manager conflict applying %v: fields owned by %v", e.Identifier, e.Managers)
+}
+
+// ErrorPartialFailure is returned alongside a partial result when a
+// fan-out operation (e.g. MultiClusterClient.List) succeeded against some
+// but not all of its targets, naming the ones that failed.
+type ErrorPartialFailure struct {
+	Clusters []string
+}
+
+func (e ErrorPartialFailure) Error() string {
+	return fmt.Sprintf("partial failure: clusters %v did not respond successfully", e.Clusters)
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package announce provides a pluggable mechanism for publishing hashrelease
+// (and, in time, other release) announcements to one or more destinations,
+// e.g. Slack, Microsoft Teams, Discord, a generic webhook, or email.
+package announce
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+)
+
+// HashreleaseMessageData carries the information needed to render a
+// hashrelease announcement, regardless of which destination(s) it is
+// ultimately published to.
+type HashreleaseMessageData struct {
+	ReleaseName        string
+	Product            string
+	Stream             string
+	ProductVersion     string
+	OperatorVersion    string
+	ReleaseType        string
+	CIURL              string
+	DocsURL            string
+	ImageScanResultURL string
+}
+
+// Announcer publishes a hashrelease announcement to a single destination.
+// Implementations should be safe to call concurrently and should treat
+// Announce as best-effort for that one destination - errors are aggregated
+// by MultiAnnouncer rather than causing other destinations to be skipped.
+type Announcer interface {
+	// Name identifies the announcer, e.g. "slack", "teams", for logging
+	// and error reporting.
+	Name() string
+
+	// Announce publishes msg to this announcer's destination.
+	Announce(ctx context.Context, msg *HashreleaseMessageData) error
+}
+
+// MultiAnnouncer fans a single announcement out to a set of Announcers
+// in parallel, aggregating any errors.
+type MultiAnnouncer struct {
+	announcers []Announcer
+}
+
+// NewMultiAnnouncer returns a MultiAnnouncer that publishes to all of the
+// given announcers. Callers should only include announcers that are
+// enabled in their configuration.
+func NewMultiAnnouncer(announcers ...Announcer) *MultiAnnouncer {
+	return &MultiAnnouncer{announcers: announcers}
+}
+
+// Announce publishes msg to every configured announcer in parallel. It
+// returns an aggregated error if one or more announcers fail, but still
+// gives every announcer a chance to run.
+func (m *MultiAnnouncer) Announce(ctx context.Context, msg *HashreleaseMessageData) error {
+	if len(m.announcers) == 0 {
+		logrus.Warn("No announcers configured, skipping hashrelease announcement")
+		return nil
+	}
+
+	type result struct {
+		name string
+		err  error
+	}
+	results := make(chan result, len(m.announcers))
+	for _, a := range m.announcers {
+		go func(a Announcer) {
+			logrus.WithField("announcer", a.Name()).Info("Sending hashrelease announcement")
+			results <- result{name: a.Name(), err: a.Announce(ctx, msg)}
+		}(a)
+	}
+
+	var errs []error
+	for range m.announcers {
+		r := <-results
+		if r.err != nil {
+			logrus.WithError(r.err).WithField("announcer", r.name).Error("Failed to send hashrelease announcement")
+			errs = append(errs, fmt.Errorf("%s: %w", r.name, r.err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%d of %d announcers failed: %w", len(errs), len(m.announcers), errors.Join(errs...))
+	}
+	return nil
+}
+
+// EmitDryRun renders payload as indented JSON, logs it at info level, and
+// writes it to sink (os.Stdout if sink is nil). Per-channel Announcers
+// call this instead of making their real network request when their
+// DryRun config flag is set, so release engineers can inspect exactly
+// what would have been posted.
+func EmitDryRun(announcerName string, payload any, sink io.Writer) error {
+	b, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s dry-run payload: %w", announcerName, err)
+	}
+
+	logrus.WithField("announcer", announcerName).Infof("[dry-run] would send:\n%s", b)
+
+	if sink == nil {
+		sink = os.Stdout
+	}
+	if _, err := sink.Write(append(b, '\n')); err != nil {
+		return fmt.Errorf("failed to write %s dry-run payload: %w", announcerName, err)
+	}
+	return nil
+}
+
+// RecordFixture writes payload as indented JSON to <dir>/<name>.json,
+// unconditionally (independent of DryRun), so integration tests can
+// golden-file-compare announcement output as message templates evolve.
+func RecordFixture(dir, name string, payload any) error {
+	b, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s fixture: %w", name, err)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create fixture dir %s: %w", dir, err)
+	}
+	return os.WriteFile(filepath.Join(dir, name+".json"), b, 0o644)
+}
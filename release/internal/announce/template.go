@@ -0,0 +1,82 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package announce
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+	"time"
+)
+
+// DefaultHashreleaseTemplate is what RenderMessage executes when it is
+// given an empty template. The announcers only call RenderMessage when
+// their Config's MessageTemplate is set; with it empty they use their own
+// built-in message layouts, not this template.
+const DefaultHashreleaseTemplate = `*{{.Product}} {{.Stream}} hashrelease*
+Version: {{.ProductVersion}}
+Operator Version: {{.OperatorVersion}}
+Built by CI: {{.CIURL}}
+Docs: {{.DocsURL}}`
+
+// TemplateData is the value a MessageTemplate is executed against. It
+// embeds every field of HashreleaseMessageData, plus derived helpers that
+// would otherwise require custom template funcs.
+type TemplateData struct {
+	HashreleaseMessageData
+
+	// Now is the time the message is being rendered, for templates that
+	// want to include it (e.g. "as of {{.Now.Format ...}}").
+	Now time.Time
+}
+
+// ShortSHA returns the first 12 characters of ReleaseName, which for
+// hashreleases is derived from the build's git SHA. It returns
+// ReleaseName unchanged if it's shorter than that.
+func (d TemplateData) ShortSHA() string {
+	if len(d.ReleaseName) <= 12 {
+		return d.ReleaseName
+	}
+	return d.ReleaseName[:12]
+}
+
+// ChangelogURL returns the DocsURL with a well-known anchor, since
+// hashrelease changelogs are posted as a thread off of the docs page
+// rather than getting their own URL.
+func (d TemplateData) ChangelogURL() string {
+	if d.DocsURL == "" {
+		return ""
+	}
+	return d.DocsURL + "#changelog"
+}
+
+// RenderMessage executes tmplText (or DefaultHashreleaseTemplate, if
+// tmplText is empty) against data and returns the resulting text.
+func RenderMessage(tmplText string, data HashreleaseMessageData) (string, error) {
+	if tmplText == "" {
+		tmplText = DefaultHashreleaseTemplate
+	}
+
+	t, err := template.New("hashrelease-message").Parse(tmplText)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse message template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, TemplateData{HashreleaseMessageData: data, Now: time.Now()}); err != nil {
+		return "", fmt.Errorf("failed to render message template: %w", err)
+	}
+	return buf.String(), nil
+}
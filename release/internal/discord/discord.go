@@ -0,0 +1,133 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package discord implements an announce.Announcer that publishes
+// hashrelease announcements to a Discord channel via an incoming webhook.
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/projectcalico/calico/release/internal/announce"
+)
+
+// Config holds the configuration needed to post hashrelease announcements
+// to a Discord channel.
+type Config struct {
+	// Enabled controls whether this announcer is included in the
+	// configured MultiAnnouncer.
+	Enabled bool
+
+	// WebhookURL is the Discord channel webhook URL.
+	WebhookURL string
+
+	// MessageTemplate, when set, overrides the built-in embed description
+	// with a Go text/template rendered against an announce.TemplateData.
+	MessageTemplate string
+
+	// DryRun, when set, renders the embed payload and emits it via
+	// DryRunSink (and logrus) instead of posting to Discord.
+	DryRun     bool
+	DryRunSink io.Writer
+}
+
+// webhookPayload is the subset of Discord's webhook execute payload that we
+// use to render an embed for the hashrelease announcement.
+type webhookPayload struct {
+	Embeds []embed `json:"embeds"`
+}
+
+type embed struct {
+	Title       string  `json:"title"`
+	URL         string  `json:"url"`
+	Description string  `json:"description,omitempty"`
+	Color       int     `json:"color"`
+	Fields      []field `json:"fields"`
+}
+
+type field struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+// Announcer posts hashrelease announcements to a Discord channel.
+type Announcer struct {
+	cfg *Config
+}
+
+// NewAnnouncer returns an announce.Announcer backed by the given Discord
+// config.
+func NewAnnouncer(cfg *Config) *Announcer {
+	return &Announcer{cfg: cfg}
+}
+
+func (a *Announcer) Name() string {
+	return "discord"
+}
+
+func (a *Announcer) Announce(ctx context.Context, msg *announce.HashreleaseMessageData) error {
+	var description string
+	if a.cfg.MessageTemplate != "" {
+		rendered, err := announce.RenderMessage(a.cfg.MessageTemplate, *msg)
+		if err != nil {
+			return err
+		}
+		description = rendered
+	}
+
+	payload := webhookPayload{
+		Embeds: []embed{{
+			Title:       fmt.Sprintf("%s %s hashrelease", msg.Product, msg.Stream),
+			URL:         msg.DocsURL,
+			Description: description,
+			Color:       0x0076D7,
+			Fields: []field{
+				{Name: "Version", Value: msg.ProductVersion, Inline: true},
+				{Name: "Operator Version", Value: msg.OperatorVersion, Inline: true},
+				{Name: "CI", Value: msg.CIURL, Inline: false},
+			},
+		}},
+	}
+
+	if a.cfg.DryRun {
+		return announce.EmitDryRun("discord", payload, a.cfg.DryRunSink)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal discord webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.cfg.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post hashrelease announcement to discord: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("discord webhook returned unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package email implements an announce.Announcer that sends hashrelease
+// announcements over SMTP.
+package email
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/smtp"
+	"strings"
+
+	"github.com/projectcalico/calico/release/internal/announce"
+)
+
+// Config holds the configuration needed to send hashrelease announcements
+// over SMTP.
+type Config struct {
+	// Enabled controls whether this announcer is included in the
+	// configured MultiAnnouncer.
+	Enabled bool
+
+	// Host and Port identify the SMTP server, e.g. "smtp.gmail.com", 587.
+	Host string
+	Port int
+
+	// Username and Password are used for PLAIN auth against Host. Leave
+	// empty to send unauthenticated.
+	Username string
+	Password string
+
+	// From is the sender address.
+	From string
+
+	// To is the list of recipient addresses.
+	To []string
+
+	// MessageTemplate, when set, overrides the built-in plain-text body
+	// with a Go text/template rendered against an announce.TemplateData.
+	MessageTemplate string
+
+	// DryRun, when set, renders the email body and emits it via
+	// DryRunSink (and logrus) instead of sending it.
+	DryRun     bool
+	DryRunSink io.Writer
+}
+
+// Announcer emails hashrelease announcements to a configured recipient
+// list.
+type Announcer struct {
+	cfg *Config
+}
+
+// NewAnnouncer returns an announce.Announcer backed by the given email
+// config.
+func NewAnnouncer(cfg *Config) *Announcer {
+	return &Announcer{cfg: cfg}
+}
+
+func (a *Announcer) Name() string {
+	return "email"
+}
+
+func (a *Announcer) Announce(ctx context.Context, msg *announce.HashreleaseMessageData) error {
+	subject := fmt.Sprintf("[hashrelease] %s %s %s", msg.Product, msg.Stream, msg.ProductVersion)
+
+	body := fmt.Sprintf(
+		"A new %s hashrelease is available.\r\n\r\n"+
+			"Version: %s\r\nOperator Version: %s\r\nStream: %s\r\nCI: %s\r\nDocs: %s\r\n",
+		msg.ReleaseType, msg.ProductVersion, msg.OperatorVersion, msg.Stream, msg.CIURL, msg.DocsURL)
+	if a.cfg.MessageTemplate != "" {
+		rendered, err := announce.RenderMessage(a.cfg.MessageTemplate, *msg)
+		if err != nil {
+			return err
+		}
+		body = rendered
+	}
+
+	msgBytes := []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s",
+		a.cfg.From, strings.Join(a.cfg.To, ", "), subject, body))
+
+	if a.cfg.DryRun {
+		return announce.EmitDryRun("email", struct {
+			To      []string
+			Subject string
+			Body    string
+		}{a.cfg.To, subject, body}, a.cfg.DryRunSink)
+	}
+
+	addr := fmt.Sprintf("%s:%d", a.cfg.Host, a.cfg.Port)
+	var auth smtp.Auth
+	if a.cfg.Username != "" {
+		auth = smtp.PlainAuth("", a.cfg.Username, a.cfg.Password, a.cfg.Host)
+	}
+	if err := smtp.SendMail(addr, auth, a.cfg.From, a.cfg.To, msgBytes); err != nil {
+		return fmt.Errorf("failed to send hashrelease announcement email: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package github provides thin helpers around the GitHub API used by the
+// release tooling, beyond what's already needed for tagging and publishing
+// releases.
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v55/github"
+)
+
+// GenerateReleaseNotesRequest describes the range to generate notes for,
+// mirroring the fields accepted by the GitHub "generate release notes"
+// API.
+type GenerateReleaseNotesRequest struct {
+	Owner string
+	Repo  string
+
+	// TagName is the (not-yet-created) tag the notes are being generated
+	// for, e.g. the current hashrelease SHA.
+	TagName string
+
+	// PreviousTagName is the SHA/tag of the last hashrelease of the same
+	// stream. If empty, GitHub picks the most recent published release.
+	PreviousTagName string
+
+	// TargetCommitish is the commit the (unpublished) TagName would point
+	// to, used when TagName does not yet exist as a tag.
+	TargetCommitish string
+}
+
+// GenerateReleaseNotes calls the GitHub "generate release notes" API
+// (POST /repos/{owner}/{repo}/releases/generate-notes) and returns the
+// resulting markdown body.
+func GenerateReleaseNotes(ctx context.Context, client *github.Client, req GenerateReleaseNotesRequest) (string, error) {
+	opts := &github.GenerateNotesOptions{
+		TagName:         req.TagName,
+		TargetCommitish: github.String(req.TargetCommitish),
+	}
+	if req.PreviousTagName != "" {
+		opts.PreviousTagName = github.String(req.PreviousTagName)
+	}
+
+	notes, _, err := client.Repositories.GenerateReleaseNotes(ctx, req.Owner, req.Repo, opts)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate release notes for %s/%s: %w", req.Owner, req.Repo, err)
+	}
+	return notes.Body, nil
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2024-2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hashreleaseserver records and retrieves the hashreleases
+// published for each release stream.
+package hashreleaseserver
+
+import "fmt"
+
+// Hashrelease describes a single published hashrelease.
+type Hashrelease struct {
+	// Name is the hashrelease's unique name, e.g. "v3.27.0-1-g123abc".
+	Name string
+
+	// Stream is the release stream this hashrelease belongs to, e.g.
+	// "master" or "release-v3.27".
+	Stream string
+
+	ProductVersion  string
+	OperatorVersion string
+
+	// ProductSHA and OperatorSHA are the git commits the hashrelease was
+	// built from, used as the range endpoints when generating a changelog
+	// against a subsequent hashrelease of the same stream.
+	ProductSHA  string
+	OperatorSHA string
+
+	// Changelog is the markdown changelog that was posted when this
+	// hashrelease was announced, kept so later hashreleases of the same
+	// stream can de-duplicate PRs already announced.
+	Changelog string
+
+	// ImageScanResultURL links to the hosted image scan report.
+	ImageScanResultURL string
+
+	// ImageScanResultPath is the local path to the raw image scan report,
+	// if one was produced, so it can be uploaded alongside the
+	// announcement instead of only linking to it.
+	ImageScanResultPath string
+}
+
+// URL returns the docs URL for this hashrelease.
+func (h *Hashrelease) URL() string {
+	return fmt.Sprintf("https://docs.eng.tigera.net/%s/release-notes/%s", h.Stream, h.Name)
+}
@@ -0,0 +1,291 @@
+// Copyright (c) 2024-2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package slack implements an announce.Announcer that publishes hashrelease
+// announcements to a Slack channel.
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	goslack "github.com/slack-go/slack"
+
+	"github.com/projectcalico/calico/release/internal/announce"
+)
+
+// Config holds the configuration needed to post hashrelease announcements
+// to a Slack channel. Token is used to drive the richer Block Kit client;
+// if only WebhookURL is set, the announcer falls back to posting through
+// the incoming webhook.
+type Config struct {
+	// Enabled controls whether this announcer is included in the
+	// configured MultiAnnouncer.
+	Enabled bool
+
+	// Token is a Slack bot token (xoxb-...) used to post via the Web API.
+	// Required for Block Kit messages, threaded replies, and file uploads.
+	Token string
+
+	// WebhookURL is a legacy incoming-webhook URL. Used only when Token
+	// is not set.
+	WebhookURL string
+
+	// Channel is the channel ID (or name, when using a webhook) to post
+	// announcements to.
+	Channel string
+
+	// MessageTemplate, when set, overrides the built-in Block Kit layout
+	// with a single markdown section rendered from this Go text/template,
+	// evaluated against an announce.TemplateData. Leave empty to keep the
+	// default header/fields/buttons layout.
+	MessageTemplate string
+
+	// DryRun, when set, renders the Block Kit payload and emits it via
+	// DryRunSink (and logrus) instead of posting to Slack.
+	DryRun     bool
+	DryRunSink io.Writer
+
+	// RecordFixtureDir, when set, additionally writes every outbound
+	// Block Kit payload to <RecordFixtureDir>/<ReleaseName>-announcement.json,
+	// independent of DryRun, so tests can golden-file-compare announcement
+	// output as the template evolves.
+	RecordFixtureDir string
+}
+
+func (c *Config) client() *goslack.Client {
+	return goslack.New(c.Token)
+}
+
+// Announcer posts hashrelease announcements to Slack as a Block Kit
+// message, rendering a header with the product/stream, a section with the
+// version/operator fields, a context block with the CI URL, and action
+// buttons linking out to the docs and image scan results.
+type Announcer struct {
+	cfg *Config
+}
+
+// NewAnnouncer returns an announce.Announcer backed by the given Slack
+// config.
+func NewAnnouncer(cfg *Config) *Announcer {
+	return &Announcer{cfg: cfg}
+}
+
+func (a *Announcer) Name() string {
+	return "slack"
+}
+
+func (a *Announcer) Announce(ctx context.Context, msg *announce.HashreleaseMessageData) error {
+	_, _, err := PostHashreleaseAnnouncement(a.cfg, msg)
+	return err
+}
+
+// blocks renders msg as the Block Kit blocks used for the main hashrelease
+// announcement message. If cfg has a MessageTemplate configured, it
+// replaces the header/fields/context layout with a single rendered
+// markdown section; the action buttons linking to docs and scan results
+// are always appended.
+func blocks(cfg *Config, msg *announce.HashreleaseMessageData) ([]goslack.Block, error) {
+	var body []goslack.Block
+	if cfg.MessageTemplate != "" {
+		text, err := announce.RenderMessage(cfg.MessageTemplate, *msg)
+		if err != nil {
+			return nil, err
+		}
+		body = []goslack.Block{
+			goslack.NewSectionBlock(goslack.NewTextBlockObject(goslack.MarkdownType, text, false, false), nil, nil),
+		}
+	} else {
+		body = defaultBlocks(msg)
+	}
+
+	buttons := []goslack.BlockElement{
+		goslack.NewButtonBlockElement("docs", msg.DocsURL, goslack.NewTextBlockObject(goslack.PlainTextType, "View docs", false, false)).WithURL(msg.DocsURL),
+	}
+	if msg.ImageScanResultURL != "" {
+		buttons = append(buttons, goslack.NewButtonBlockElement("scan", msg.ImageScanResultURL,
+			goslack.NewTextBlockObject(goslack.PlainTextType, "View image scan results", false, false)).WithURL(msg.ImageScanResultURL))
+	}
+
+	return append(body, goslack.NewActionBlock("hashrelease_links", buttons...)), nil
+}
+
+// defaultBlocks renders the built-in header/fields/context layout used
+// when no MessageTemplate is configured.
+func defaultBlocks(msg *announce.HashreleaseMessageData) []goslack.Block {
+	header := goslack.NewHeaderBlock(
+		goslack.NewTextBlockObject(goslack.PlainTextType, fmt.Sprintf("%s %s hashrelease", msg.Product, msg.Stream), false, false),
+	)
+
+	fields := []*goslack.TextBlockObject{
+		goslack.NewTextBlockObject(goslack.MarkdownType, fmt.Sprintf("*Version:*\n%s", msg.ProductVersion), false, false),
+		goslack.NewTextBlockObject(goslack.MarkdownType, fmt.Sprintf("*Operator Version:*\n%s", msg.OperatorVersion), false, false),
+		goslack.NewTextBlockObject(goslack.MarkdownType, fmt.Sprintf("*Stream:*\n%s", msg.Stream), false, false),
+		goslack.NewTextBlockObject(goslack.MarkdownType, fmt.Sprintf("*Type:*\n%s", msg.ReleaseType), false, false),
+	}
+	section := goslack.NewSectionBlock(nil, fields, nil)
+
+	context := goslack.NewContextBlock("", goslack.NewTextBlockObject(goslack.MarkdownType, fmt.Sprintf("Built by <%s|CI>", msg.CIURL), false, false))
+
+	return []goslack.Block{header, section, context}
+}
+
+// PostHashreleaseAnnouncement posts a hashrelease announcement to Slack,
+// returning the channel and timestamp of the posted message so callers can
+// thread follow-up messages (e.g. changelogs, scan reports) off of it.
+func PostHashreleaseAnnouncement(cfg *Config, msg *announce.HashreleaseMessageData) (channel, ts string, err error) {
+	logrus.WithField("hashrelease", msg.ReleaseName).Info("Sending hashrelease announcement to Slack")
+
+	b, err := blocks(cfg, msg)
+	if err != nil {
+		return "", "", err
+	}
+
+	payload := goslack.Blocks{BlockSet: b}
+
+	if cfg.RecordFixtureDir != "" {
+		if err := announce.RecordFixture(cfg.RecordFixtureDir, msg.ReleaseName+"-announcement", payload); err != nil {
+			logrus.WithError(err).Warn("Failed to record slack announcement fixture")
+		}
+	}
+
+	if cfg.DryRun {
+		if err := announce.EmitDryRun("slack", payload, cfg.DryRunSink); err != nil {
+			return "", "", err
+		}
+		return cfg.Channel, "dry-run", nil
+	}
+
+	if cfg.Token == "" {
+		// Fall back to the legacy incoming webhook, which only supports a
+		// flat list of blocks and doesn't return a timestamp.
+		if cfg.WebhookURL == "" {
+			return "", "", fmt.Errorf("slack: neither Token nor WebhookURL configured")
+		}
+		if err := goslack.PostWebhook(cfg.WebhookURL, &goslack.WebhookMessage{Blocks: &goslack.Blocks{BlockSet: b}}); err != nil {
+			return "", "", fmt.Errorf("failed to post hashrelease announcement to slack webhook: %w", err)
+		}
+		return cfg.Channel, "", nil
+	}
+
+	channel, ts, err = cfg.client().PostMessageContext(context.Background(), cfg.Channel, goslack.MsgOptionBlocks(b...))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to post hashrelease announcement to slack: %w", err)
+	}
+	return channel, ts, nil
+}
+
+// PostHashreleaseChangelog posts notes as a threaded reply under the
+// message identified by parentTS, which should be the ts returned by
+// PostHashreleaseAnnouncement for the same channel. It is a no-op (and
+// returns an error) if cfg has no Token, since threaded replies aren't
+// supported through the legacy incoming webhook.
+func PostHashreleaseChangelog(cfg *Config, channel, parentTS, notes string) error {
+	if cfg.Token == "" {
+		return fmt.Errorf("slack: posting a threaded changelog requires Token, not WebhookURL")
+	}
+	if parentTS == "" {
+		return fmt.Errorf("slack: missing parent ts to thread changelog under")
+	}
+
+	_, _, err := cfg.client().PostMessageContext(context.Background(), channel,
+		goslack.MsgOptionText(notes, false),
+		goslack.MsgOptionTS(parentTS),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to post hashrelease changelog to slack: %w", err)
+	}
+	return nil
+}
+
+// PostHashreleaseScanReport uploads the image scan report at path into the
+// hashrelease announcement thread, using Slack's files.upload v2 flow
+// (files.getUploadURLExternal + files.completeUploadExternal), which
+// handles chunking large files internally. The initial comment summarizes
+// the critical/high CVE counts found in the report, when they can be
+// parsed.
+//
+// This requires cfg.Token - there is no webhook-only equivalent, so
+// callers should fall back to the URL-only announcement (already linked
+// via the "View image scan results" button) when cfg has no Token or the
+// upload fails.
+func PostHashreleaseScanReport(cfg *Config, channel, parentTS, path string) error {
+	if cfg.Token == "" {
+		return fmt.Errorf("slack: uploading the scan report requires Token, not WebhookURL")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to stat image scan report %s: %w", path, err)
+	}
+
+	_, err = cfg.client().UploadFileV2(goslack.UploadFileV2Parameters{
+		File:            path,
+		Filename:        info.Name(),
+		FileSize:        int(info.Size()),
+		Channel:         channel,
+		ThreadTimestamp: parentTS,
+		InitialComment:  scanReportSummary(path),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload image scan report to slack: %w", err)
+	}
+	return nil
+}
+
+// scanReport is the subset of a JSON scan report we need in order to
+// summarize critical/high findings. Reports we can't
+// parse against this shape are uploaded without a severity summary.
+type scanReport struct {
+	Vulnerabilities []struct {
+		Severity string `json:"severity"`
+	} `json:"vulnerabilities"`
+}
+
+// scanReportSummary best-effort parses the scan report at path and
+// returns a one-line summary of critical/high CVE counts suitable for use
+// as a Slack file upload's initial_comment.
+func scanReportSummary(path string) string {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		logrus.WithError(err).WithField("path", path).Warn("Failed to read image scan report for summary")
+		return "Image scan report"
+	}
+
+	var report scanReport
+	if err := json.Unmarshal(raw, &report); err != nil {
+		// Not JSON (e.g. HTML) - fall back to a generic comment.
+		return "Image scan report"
+	}
+	if report.Vulnerabilities == nil {
+		// JSON without a vulnerabilities list (e.g. SARIF) - fall back to a
+		// generic comment rather than reporting zero findings.
+		return "Image scan report"
+	}
+
+	var critical, high int
+	for _, v := range report.Vulnerabilities {
+		switch v.Severity {
+		case "CRITICAL", "Critical":
+			critical++
+		case "HIGH", "High":
+			high++
+		}
+	}
+	return fmt.Sprintf("Image scan report: %d critical, %d high severity findings", critical, high)
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slack
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/projectcalico/calico/release/internal/announce"
+)
+
+func testMessage() *announce.HashreleaseMessageData {
+	return &announce.HashreleaseMessageData{
+		ReleaseName:        "v3.30.0-1-g1234567-abcdefabcdef",
+		Product:            "calico",
+		Stream:             "master",
+		ProductVersion:     "v3.30.0",
+		OperatorVersion:    "v1.36.0",
+		ReleaseType:        "hashrelease",
+		CIURL:              "https://ci.example.com/builds/1",
+		DocsURL:            "https://latest-os.docs.eng.tigera.net/v3.30.0-1-g1234567",
+		ImageScanResultURL: "https://scan.example.com/report/1",
+	}
+}
+
+func TestPostHashreleaseAnnouncementDryRun(t *testing.T) {
+	var sink bytes.Buffer
+	cfg := &Config{
+		Enabled:    true,
+		Token:      "xoxb-test",
+		Channel:    "C0123456",
+		DryRun:     true,
+		DryRunSink: &sink,
+	}
+
+	channel, ts, err := PostHashreleaseAnnouncement(cfg, testMessage())
+	if err != nil {
+		t.Fatalf("PostHashreleaseAnnouncement returned error: %v", err)
+	}
+	if channel != cfg.Channel {
+		t.Errorf("expected channel %q, got %q", cfg.Channel, channel)
+	}
+	if ts == "" {
+		t.Error("expected a non-empty ts placeholder in dry-run mode")
+	}
+	if sink.Len() == 0 {
+		t.Fatal("expected dry-run payload to be written to the sink")
+	}
+
+	var payload goslackBlocks
+	if err := json.Unmarshal(sink.Bytes(), &payload); err != nil {
+		t.Fatalf("dry-run payload is not valid JSON: %v", err)
+	}
+	if len(payload) == 0 {
+		t.Error("expected dry-run payload to contain blocks")
+	}
+}
+
+// goslackBlocks mirrors the JSON form of goslack.Blocks, whose MarshalJSON
+// emits its BlockSet as a bare array of blocks.
+type goslackBlocks []json.RawMessage
@@ -0,0 +1,136 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package teams implements an announce.Announcer that publishes
+// hashrelease announcements to a Microsoft Teams channel via an incoming
+// webhook connector.
+package teams
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/projectcalico/calico/release/internal/announce"
+)
+
+// Config holds the configuration needed to post hashrelease announcements
+// to a Microsoft Teams channel.
+type Config struct {
+	// Enabled controls whether this announcer is included in the
+	// configured MultiAnnouncer.
+	Enabled bool
+
+	// WebhookURL is the Teams incoming webhook connector URL.
+	WebhookURL string
+
+	// MessageTemplate, when set, overrides the built-in card text with a
+	// Go text/template rendered against an announce.TemplateData.
+	MessageTemplate string
+
+	// DryRun, when set, renders the message card and emits it via
+	// DryRunSink (and logrus) instead of posting to Teams.
+	DryRun     bool
+	DryRunSink io.Writer
+}
+
+// card is a minimal Office 365 connector "MessageCard".
+type card struct {
+	Type       string    `json:"@type"`
+	Context    string    `json:"@context"`
+	Summary    string    `json:"summary"`
+	ThemeColor string    `json:"themeColor"`
+	Title      string    `json:"title"`
+	Text       string    `json:"text"`
+	Sections   []section `json:"sections"`
+}
+
+type section struct {
+	Facts []fact `json:"facts"`
+}
+
+type fact struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Announcer posts hashrelease announcements to a Microsoft Teams channel.
+type Announcer struct {
+	cfg *Config
+}
+
+// NewAnnouncer returns an announce.Announcer backed by the given Teams
+// config.
+func NewAnnouncer(cfg *Config) *Announcer {
+	return &Announcer{cfg: cfg}
+}
+
+func (a *Announcer) Name() string {
+	return "teams"
+}
+
+func (a *Announcer) Announce(ctx context.Context, msg *announce.HashreleaseMessageData) error {
+	text := fmt.Sprintf("Built by [CI](%s) - [docs](%s)", msg.CIURL, msg.DocsURL)
+	if a.cfg.MessageTemplate != "" {
+		rendered, err := announce.RenderMessage(a.cfg.MessageTemplate, *msg)
+		if err != nil {
+			return err
+		}
+		text = rendered
+	}
+
+	c := card{
+		Type:       "MessageCard",
+		Context:    "http://schema.org/extensions",
+		Summary:    fmt.Sprintf("%s %s hashrelease", msg.Product, msg.Stream),
+		ThemeColor: "0076D7",
+		Title:      fmt.Sprintf("%s %s hashrelease", msg.Product, msg.Stream),
+		Text:       text,
+		Sections: []section{{
+			Facts: []fact{
+				{Name: "Version", Value: msg.ProductVersion},
+				{Name: "Operator Version", Value: msg.OperatorVersion},
+				{Name: "Stream", Value: msg.Stream},
+			},
+		}},
+	}
+
+	if a.cfg.DryRun {
+		return announce.EmitDryRun("teams", c, a.cfg.DryRunSink)
+	}
+
+	body, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to marshal teams message card: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.cfg.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post hashrelease announcement to teams: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("teams webhook returned unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package webhook implements an announce.Announcer that POSTs hashrelease
+// announcements as raw JSON to an arbitrary HTTP endpoint, for operators
+// who want to consume hashrelease events in their own tooling.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/projectcalico/calico/release/internal/announce"
+)
+
+// Config holds the configuration needed to POST hashrelease announcements
+// to a generic webhook.
+type Config struct {
+	// Enabled controls whether this announcer is included in the
+	// configured MultiAnnouncer.
+	Enabled bool
+
+	// URL is the endpoint to POST the announcement JSON to.
+	URL string
+
+	// Headers are additional headers to set on the request, e.g. for
+	// authentication.
+	Headers map[string]string
+
+	// DryRun, when set, renders the JSON payload and emits it via
+	// DryRunSink (and logrus) instead of POSTing it.
+	DryRun     bool
+	DryRunSink io.Writer
+}
+
+// Announcer POSTs the raw HashreleaseMessageData as JSON to a configured
+// URL.
+type Announcer struct {
+	cfg *Config
+}
+
+// NewAnnouncer returns an announce.Announcer backed by the given webhook
+// config.
+func NewAnnouncer(cfg *Config) *Announcer {
+	return &Announcer{cfg: cfg}
+}
+
+func (a *Announcer) Name() string {
+	return "webhook"
+}
+
+func (a *Announcer) Announce(ctx context.Context, msg *announce.HashreleaseMessageData) error {
+	if a.cfg.DryRun {
+		return announce.EmitDryRun("webhook", msg, a.cfg.DryRunSink)
+	}
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal hashrelease announcement: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range a.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post hashrelease announcement to webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	ghclient "github.com/google/go-github/v55/github"
+
+	"github.com/projectcalico/calico/release/internal/github"
+	"github.com/projectcalico/calico/release/internal/hashreleaseserver"
+)
+
+const (
+	calicoOwner   = "projectcalico"
+	calicoRepo    = "calico"
+	operatorOwner = "tigera"
+	operatorRepo  = "operator"
+)
+
+// prReferenceRE matches a GitHub PR reference of the form "(#1234)" as
+// emitted at the end of each line by the "generate release notes" API,
+// used to de-duplicate entries that were already announced for the
+// previous hashrelease of the same stream.
+var prReferenceRE = regexp.MustCompile(`\(#(\d+)\)`)
+
+// buildChangelog computes the markdown changelog between previous and
+// current, by asking GitHub to generate release notes for both
+// projectcalico/calico and tigera/operator between the two hashreleases'
+// recorded SHAs, then stripping any PR already present in previous's own
+// changelog so that long-lived streams don't repeat entries every time.
+//
+// since, when non-empty, overrides the previous hashrelease's SHA as the
+// start of the range - useful for regenerating a changelog further back
+// than the immediately preceding hashrelease.
+func buildChangelog(ctx context.Context, client *ghclient.Client, current, previous *hashreleaseserver.Hashrelease, since string) (string, error) {
+	productPrev := since
+	operatorPrev := since
+	var alreadyAnnounced map[string]struct{}
+	if previous != nil {
+		if productPrev == "" {
+			productPrev = previous.ProductSHA
+		}
+		if operatorPrev == "" {
+			operatorPrev = previous.OperatorSHA
+		}
+		alreadyAnnounced = extractPRNumbers(previous.Changelog)
+	}
+
+	var sections []string
+	productNotes, err := github.GenerateReleaseNotes(ctx, client, github.GenerateReleaseNotesRequest{
+		Owner:           calicoOwner,
+		Repo:            calicoRepo,
+		TagName:         current.ProductSHA,
+		PreviousTagName: productPrev,
+		TargetCommitish: current.ProductSHA,
+	})
+	if err != nil {
+		return "", err
+	}
+	if notes := dedupePRs(productNotes, alreadyAnnounced); notes != "" {
+		sections = append(sections, fmt.Sprintf("*%s changes:*\n%s", calicoRepo, notes))
+	}
+
+	operatorNotes, err := github.GenerateReleaseNotes(ctx, client, github.GenerateReleaseNotesRequest{
+		Owner:           operatorOwner,
+		Repo:            operatorRepo,
+		TagName:         current.OperatorSHA,
+		PreviousTagName: operatorPrev,
+		TargetCommitish: current.OperatorSHA,
+	})
+	if err != nil {
+		return "", err
+	}
+	if notes := dedupePRs(operatorNotes, alreadyAnnounced); notes != "" {
+		sections = append(sections, fmt.Sprintf("*%s changes:*\n%s", operatorRepo, notes))
+	}
+
+	return strings.Join(sections, "\n\n"), nil
+}
+
+// extractPRNumbers returns the set of PR numbers referenced in notes.
+func extractPRNumbers(notes string) map[string]struct{} {
+	out := map[string]struct{}{}
+	for _, m := range prReferenceRE.FindAllStringSubmatch(notes, -1) {
+		out[m[1]] = struct{}{}
+	}
+	return out
+}
+
+// dedupePRs drops any line of notes whose PR reference appears in seen.
+func dedupePRs(notes string, seen map[string]struct{}) string {
+	if len(seen) == 0 {
+		return notes
+	}
+	var kept []string
+	for _, line := range strings.Split(notes, "\n") {
+		m := prReferenceRE.FindStringSubmatch(line)
+		if m != nil {
+			if _, ok := seen[m[1]]; ok {
+				continue
+			}
+		}
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "\n")
+}
@@ -15,19 +15,94 @@
 package tasks
 
 import (
+	"context"
+	"errors"
+
+	ghclient "github.com/google/go-github/v55/github"
 	"github.com/sirupsen/logrus"
 
+	"github.com/projectcalico/calico/release/internal/announce"
+	"github.com/projectcalico/calico/release/internal/discord"
+	"github.com/projectcalico/calico/release/internal/email"
 	"github.com/projectcalico/calico/release/internal/hashreleaseserver"
 	"github.com/projectcalico/calico/release/internal/slack"
+	"github.com/projectcalico/calico/release/internal/teams"
 	"github.com/projectcalico/calico/release/internal/utils"
+	"github.com/projectcalico/calico/release/internal/webhook"
 )
 
 var product = utils.ProductName
 
-// AnnounceHashrelease sends a slack notification for a new hashrelease.
-func AnnounceHashrelease(cfg *slack.Config, hashrel *hashreleaseserver.Hashrelease, ciURL string) error {
-	logrus.WithField("hashrelease", hashrel.Name).Info("Sending hashrelease announcement to Slack")
-	msgData := &slack.HashreleaseMessageData{
+// AnnouncerConfig collects the per-destination configuration for every
+// announcer that AnnounceHashrelease knows how to publish to. Each
+// destination is only included in the fan-out if its Enabled field is set.
+type AnnouncerConfig struct {
+	Slack   *slack.Config
+	Teams   *teams.Config
+	Discord *discord.Config
+	Webhook *webhook.Config
+	Email   *email.Config
+
+	// DryRun, when set, is pushed down onto every enabled destination's
+	// own DryRun field before announcing, so none of them make a real
+	// network call - each instead renders and logs the payload it would
+	// have sent.
+	DryRun bool
+}
+
+// applyDryRun copies DryRun onto every enabled destination config.
+func (c *AnnouncerConfig) applyDryRun() {
+	if !c.DryRun {
+		return
+	}
+	if c.Slack != nil {
+		c.Slack.DryRun = true
+	}
+	if c.Teams != nil {
+		c.Teams.DryRun = true
+	}
+	if c.Discord != nil {
+		c.Discord.DryRun = true
+	}
+	if c.Webhook != nil {
+		c.Webhook.DryRun = true
+	}
+	if c.Email != nil {
+		c.Email.DryRun = true
+	}
+}
+
+// nonSlackAnnouncers returns the enabled announce.Announcers for every
+// destination except Slack, which AnnounceHashrelease drives directly so
+// that it can thread a generated changelog under the main message.
+func (c *AnnouncerConfig) nonSlackAnnouncers() []announce.Announcer {
+	var out []announce.Announcer
+	if c.Teams != nil && c.Teams.Enabled {
+		out = append(out, teams.NewAnnouncer(c.Teams))
+	}
+	if c.Discord != nil && c.Discord.Enabled {
+		out = append(out, discord.NewAnnouncer(c.Discord))
+	}
+	if c.Webhook != nil && c.Webhook.Enabled {
+		out = append(out, webhook.NewAnnouncer(c.Webhook))
+	}
+	if c.Email != nil && c.Email.Enabled {
+		out = append(out, email.NewAnnouncer(c.Email))
+	}
+	return out
+}
+
+// AnnounceHashrelease publishes a hashrelease announcement to every
+// destination configured and enabled in cfg.
+//
+// If cfg.Slack is enabled, the changelog between previous (the last
+// hashrelease announced for the same stream, or nil) and hashrel is
+// computed via the GitHub "generate release notes" API and posted as a
+// threaded reply under the main Slack announcement. since, when set,
+// overrides previous's recorded SHA as the start of that changelog range.
+func AnnounceHashrelease(ghc *ghclient.Client, cfg *AnnouncerConfig, hashrel, previous *hashreleaseserver.Hashrelease, ciURL, since string) error {
+	logrus.WithField("hashrelease", hashrel.Name).Info("Sending hashrelease announcement")
+	msgData := &announce.HashreleaseMessageData{
 		ReleaseName:        hashrel.Name,
 		Product:            product,
 		Stream:             hashrel.Stream,
@@ -38,5 +113,47 @@ func AnnounceHashrelease(cfg *slack.Config, hashrel *hashreleaseserver.Hashrelea
 		DocsURL:            hashrel.URL(),
 		ImageScanResultURL: hashrel.ImageScanResultURL,
 	}
-	return slack.PostHashreleaseAnnouncement(cfg, msgData)
+
+	cfg.applyDryRun()
+
+	ctx := context.Background()
+	var errs []error
+
+	if cfg.Slack != nil && cfg.Slack.Enabled {
+		channel, ts, err := slack.PostHashreleaseAnnouncement(cfg.Slack, msgData)
+		if err != nil {
+			errs = append(errs, err)
+		} else if cfg.Slack.DryRun {
+			logrus.Info("Dry-run: skipping changelog thread and scan report upload")
+		} else {
+			if notes, err := buildChangelog(ctx, ghc, hashrel, previous, since); err != nil {
+				logrus.WithError(err).Warn("Failed to generate hashrelease changelog, skipping threaded reply")
+			} else if notes != "" {
+				// Persist the generated changelog onto the record being
+				// announced, not just the in-memory notes string, so
+				// the next hashrelease of this stream can dedupe PRs
+				// against it via buildChangelog's alreadyAnnounced set.
+				hashrel.Changelog = notes
+				if err := slack.PostHashreleaseChangelog(cfg.Slack, channel, ts, notes); err != nil {
+					logrus.WithError(err).Warn("Failed to post hashrelease changelog to slack")
+				}
+			}
+
+			// Best-effort: attach the raw scan report so reviewers don't
+			// have to leave Slack. The URL-only button in the main
+			// message already covers the fallback case.
+			if hashrel.ImageScanResultPath != "" {
+				if err := slack.PostHashreleaseScanReport(cfg.Slack, channel, ts, hashrel.ImageScanResultPath); err != nil {
+					logrus.WithError(err).Warn("Failed to upload image scan report to slack, falling back to URL-only announcement")
+				}
+			}
+		}
+	}
+
+	ma := announce.NewMultiAnnouncer(cfg.nonSlackAnnouncers()...)
+	if err := ma.Announce(ctx, msgData); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
 }